@@ -0,0 +1,64 @@
+package gen
+
+import "github.com/juju/errors"
+
+// Body contents declared with the content attribute: `-> Request: content = form`
+const (
+	contentJSON      = "json"
+	contentForm      = "form"
+	contentMultipart = "multipart"
+	contentText      = "text"
+	contentBool      = "bool"
+	contentBinary    = "binary"
+)
+
+var requestFormatPerContent = map[string]RequestFormat{
+	contentJSON:      JSONRequest,
+	contentForm:      FormRequest,
+	contentMultipart: MultipartRequest,
+}
+
+// responseKindPerContent contains the response kinds that don't depend on the body example.
+// JSON responses are not here, as their kind is taken from the example
+var responseKindPerContent = map[string]ResponseKind{
+	contentText:   TextResponse,
+	contentBool:   BoolResponse,
+	contentBinary: BinaryResponse,
+}
+
+// responseFormatPerKind contains the contents of the non JSON response kinds
+var responseFormatPerKind = map[ResponseKind]string{
+	TextResponse:   contentText,
+	BoolResponse:   contentBool,
+	BinaryResponse: contentBinary,
+}
+
+func requestFormatFromContent(content string) (RequestFormat, error) {
+	if content == "" {
+		return JSONRequest, nil
+	}
+	format, found := requestFormatPerContent[content]
+	if !found {
+		return JSONRequest, errors.Annotatef(ErrInvalidContent, "%q is not a request content. Use %q, %q or %q", content, contentJSON, contentForm, contentMultipart)
+	}
+	return format, nil
+}
+
+func responseKindFromContent(content string, body interface{}, forceAsMap, raw bool) (ResponseKind, error) {
+	if content == "" || content == contentJSON {
+		return getResponseKind(body, forceAsMap, raw), nil
+	}
+	kind, found := responseKindPerContent[content]
+	if !found {
+		return EmptyResponse, errors.Annotatef(ErrInvalidContent, "%q is not a response content. Use %q, %q, %q or %q", content, contentJSON, contentText, contentBool, contentBinary)
+	}
+	return kind, nil
+}
+
+// responseFormat returns the content of the response: json for all the kinds parsed from JSON
+func (epi endpointInfo) responseFormat() string {
+	if format, found := responseFormatPerKind[epi.ResponseKind]; found {
+		return format
+	}
+	return contentJSON
+}
@@ -0,0 +1,99 @@
+package gen
+
+import (
+	"testing"
+
+	"github.com/alvaroloes/sdkgen/parser"
+	"github.com/alvaroloes/sdkgen/tests"
+)
+
+type bodyContentsTestCase struct {
+	name                  string
+	endpoint              parser.Endpoint
+	expectedRequestFormat RequestFormat
+	expectedResponseKind  ResponseKind
+	expectedError         error
+}
+
+var bodyContentsTestCases = []bodyContentsTestCase{
+	{
+		name: "JSON bodies by default",
+		endpoint: parser.Endpoint{
+			Method:       parser.POST,
+			RequestBody:  map[string]interface{}{"title": "Title"},
+			ResponseBody: map[string]interface{}{"id": "1"},
+		},
+		expectedRequestFormat: JSONRequest,
+		expectedResponseKind:  ModelResponse,
+	}, {
+		name: "Form request and text response",
+		endpoint: parser.Endpoint{
+			Method:       parser.POST,
+			RequestSpec:  "content = form",
+			RequestBody:  map[string]interface{}{"title": "Title"},
+			ResponseSpec: "content = text",
+		},
+		expectedRequestFormat: FormRequest,
+		expectedResponseKind:  TextResponse,
+	}, {
+		name: "Multipart request and bool response",
+		endpoint: parser.Endpoint{
+			Method:       parser.POST,
+			RequestSpec:  "content = multipart",
+			RequestBody:  map[string]interface{}{"image: type = file": "photo.png"},
+			ResponseSpec: "content = bool",
+		},
+		expectedRequestFormat: MultipartRequest,
+		expectedResponseKind:  BoolResponse,
+	}, {
+		name: "Binary response",
+		endpoint: parser.Endpoint{
+			Method:       parser.GET,
+			ResponseSpec: "content = binary",
+		},
+		expectedRequestFormat: JSONRequest,
+		expectedResponseKind:  BinaryResponse,
+	}, {
+		name: "Explicit JSON response",
+		endpoint: parser.Endpoint{
+			Method:       parser.GET,
+			ResponseSpec: "content = json",
+			ResponseBody: []interface{}{map[string]interface{}{"id": "1"}},
+		},
+		expectedRequestFormat: JSONRequest,
+		expectedResponseKind:  ArrayResponse,
+	}, {
+		name: "Invalid request content",
+		endpoint: parser.Endpoint{
+			Method:      parser.POST,
+			RequestSpec: "content = text",
+		},
+		expectedError: ErrInvalidContent,
+	}, {
+		name: "Invalid response content",
+		endpoint: parser.Endpoint{
+			Method:       parser.GET,
+			ResponseSpec: "content = xml",
+		},
+		expectedError: ErrInvalidContent,
+	},
+}
+
+func TestBodyContents(t *testing.T) {
+	for _, testCase := range bodyContentsTestCases {
+		testCase.endpoint.URL = tests.MustParseURL("https://www.alvarloes.com/posts")
+		testCase.endpoint.Resources = []parser.Resource{{Name: "posts"}}
+		gen, ok := extractTestModelsInfo(t, testCase.name, &parser.API{Endpoints: []parser.Endpoint{testCase.endpoint}}, Config{}, testCase.expectedError)
+		if !ok {
+			continue
+		}
+
+		epi := gen.modelsInfo["post"].EndpointsInfo[0]
+		if epi.RequestFormat != testCase.expectedRequestFormat {
+			t.Errorf("Test %q: Expected request format %s, got: %s", testCase.name, testCase.expectedRequestFormat, epi.RequestFormat)
+		}
+		if epi.ResponseKind != testCase.expectedResponseKind {
+			t.Errorf("Test %q: Expected response kind %s, got: %s", testCase.name, testCase.expectedResponseKind, epi.ResponseKind)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package gen
+
+import (
+	"encoding/json"
+	"regexp"
+	"time"
+)
+
+// typeDate is the property type used for dates. It can be forced with "type = date"
+const typeDate = "date"
+
+// Supported date formats. They can be forced with "format = <dateFormat>".
+// The generated code relies on these names, so they must be kept in sync with the templates
+const (
+	dateFormatRFC3339      = "rfc3339"
+	dateFormatISODate      = "isoDate"
+	dateFormatEpochSeconds = "epochSeconds"
+	dateFormatEpochMillis  = "epochMillis"
+)
+
+// dateFormatPerName contains the accepted format names, including aliases
+var dateFormatPerName = map[string]string{
+	dateFormatRFC3339:      dateFormatRFC3339,
+	"iso8601":              dateFormatRFC3339,
+	dateFormatISODate:      dateFormatISODate,
+	dateFormatEpochSeconds: dateFormatEpochSeconds,
+	dateFormatEpochMillis:  dateFormatEpochMillis,
+}
+
+const isoDateLayout = "2006-01-02"
+
+// Epoch values are only detected in properties whose name looks like a date, as
+// otherwise any big number would be taken as a date
+var (
+	dateNameRegexp          = regexp.MustCompile(`^(date|time|timestamp)$|(At|_at|Date|_date|Time|_time|Timestamp|_timestamp)$`)
+	epochSecondsValueRegexp = regexp.MustCompile(`^[0-9]{10}$`)
+	epochMillisValueRegexp  = regexp.MustCompile(`^[0-9]{13}$`)
+)
+
+// dateFormatFromValue returns the date format of the value or an empty string
+// if the value is not a date. It also returns whether the date is encoded in a string.
+// Epoch dates are only detected if allowEpoch is true
+func dateFormatFromValue(val interface{}, allowEpoch bool) (format string, inString bool) {
+	var literal string
+	switch v := val.(type) {
+	case string:
+		if _, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return dateFormatRFC3339, true
+		}
+		if _, err := time.Parse(isoDateLayout, v); err == nil {
+			return dateFormatISODate, true
+		}
+		literal, inString = v, true
+	case json.Number:
+		literal = v.String()
+	default:
+		return "", false
+	}
+
+	if !allowEpoch {
+		return "", false
+	}
+	switch {
+	case epochSecondsValueRegexp.MatchString(literal):
+		return dateFormatEpochSeconds, inString
+	case epochMillisValueRegexp.MatchString(literal):
+		return dateFormatEpochMillis, inString
+	}
+	return "", false
+}
+
+// looksLikeDateName returns whether the property name suggests it contains a date
+func looksLikeDateName(propName string) bool {
+	return dateNameRegexp.MatchString(propName)
+}
+
+// isDate returns whether the property represents a date
+func (p property) isDate() bool {
+	return p.Type == typeDate
+}
+
+// canHoldDate returns whether the property type is one that is used to encode dates
+func (p property) canHoldDate() bool {
+	return p.Type == "string" || p.isNumeric()
+}
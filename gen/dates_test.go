@@ -0,0 +1,82 @@
+package gen
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/alvaroloes/sdkgen/parser"
+	"github.com/alvaroloes/sdkgen/tests"
+)
+
+type datePropertiesTestCase struct {
+	name            string
+	responseBody    interface{}
+	expectedFormats map[string]string
+	expectedError   error
+}
+
+var datePropertiesTestCases = []datePropertiesTestCase{
+	{
+		name: "Formats inferred from the values",
+		responseBody: map[string]interface{}{
+			"createdAt": "1457299698278",
+			"updatedAt": json.Number("1457299698"),
+			"birthday":  "1980-02-01",
+			"lastLogin": "2016-03-06T21:28:18.123+01:00",
+		},
+		expectedFormats: map[string]string{
+			"createdAt": "epochMillis",
+			"updatedAt": "epochSeconds",
+			"birthday":  "isoDate",
+			"lastLogin": "rfc3339",
+		},
+	}, {
+		name: "Epoch numbers only in date names",
+		responseBody: map[string]interface{}{
+			"followers": json.Number("1457299698"),
+		},
+		expectedFormats: map[string]string{"followers": ""},
+	}, {
+		name: "Format declared in the attributes",
+		responseBody: map[string]interface{}{
+			"expires: format = epochSeconds": json.Number("3600"),
+		},
+		expectedFormats: map[string]string{"expires": "epochSeconds"},
+	}, {
+		name: "Unknown format",
+		responseBody: map[string]interface{}{
+			"expires: format = epochMinutes": json.Number("3600"),
+		},
+		expectedError: ErrInvalidDateFormat,
+	},
+}
+
+func TestDateProperties(t *testing.T) {
+	for _, testCase := range datePropertiesTestCases {
+		api := &parser.API{
+			Endpoints: []parser.Endpoint{
+				{
+					Method:       parser.GET,
+					URL:          tests.MustParseURL("https://www.alvarloes.com/people"),
+					Resources:    []parser.Resource{{Name: "people"}},
+					ResponseBody: testCase.responseBody,
+				},
+			},
+		}
+		gen, ok := extractTestModelsInfo(t, testCase.name, api, Config{}, testCase.expectedError)
+		if !ok {
+			continue
+		}
+
+		props := gen.modelsInfo["person"].Properties
+		for propName, dateFormat := range testCase.expectedFormats {
+			prop := props[propName]
+			if dateFormat != "" && prop.Type != typeDate {
+				t.Errorf("Test %q: Expected property %q to be a date, got: %q", testCase.name, propName, prop.Type)
+			}
+			if prop.DateFormat != dateFormat {
+				t.Errorf("Test %q: Expected property %q date format %q, got: %q", testCase.name, propName, dateFormat, prop.DateFormat)
+			}
+		}
+	}
+}
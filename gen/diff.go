@@ -0,0 +1,141 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// diffContextLines is the number of unchanged lines shown around the changes
+const diffContextLines = 3
+
+type diffOp struct {
+	kind byte // ' ' for unchanged lines, '-' for removed ones and '+' for added ones
+	line string
+}
+
+// unifiedDiff returns the changes from the first text to the second one in unified format.
+// It is empty if both texts are equal
+func unifiedDiff(fromName, toName, from, to string) string {
+	ops := diffLines(splitLines(from), splitLines(to))
+
+	// fromLines[i] and toLines[i] are the lines of each text before the operation i
+	fromLines := make([]int, len(ops)+1)
+	toLines := make([]int, len(ops)+1)
+	for i, op := range ops {
+		fromLines[i+1], toLines[i+1] = fromLines[i], toLines[i]
+		if op.kind != '+' {
+			fromLines[i+1]++
+		}
+		if op.kind != '-' {
+			toLines[i+1]++
+		}
+	}
+
+	var diff bytes.Buffer
+	for start := 0; start < len(ops); {
+		firstChange := start
+		for firstChange < len(ops) && ops[firstChange].kind == ' ' {
+			firstChange++
+		}
+		if firstChange == len(ops) {
+			break
+		}
+		// Changes closer than twice the context go in the same hunk
+		lastChange := firstChange
+		for i := firstChange; i < len(ops) && i-lastChange <= 2*diffContextLines; i++ {
+			if ops[i].kind != ' ' {
+				lastChange = i
+			}
+		}
+		hunkStart := maxInt(firstChange-diffContextLines, start)
+		hunkEnd := minInt(lastChange+diffContextLines+1, len(ops))
+
+		if diff.Len() == 0 {
+			fmt.Fprintf(&diff, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		fmt.Fprintf(&diff, "@@ -%s +%s @@\n",
+			hunkRange(fromLines[hunkStart], fromLines[hunkEnd]-fromLines[hunkStart]),
+			hunkRange(toLines[hunkStart], toLines[hunkEnd]-toLines[hunkStart]))
+		for _, op := range ops[hunkStart:hunkEnd] {
+			diff.WriteByte(op.kind)
+			diff.WriteString(op.line)
+			diff.WriteByte('\n')
+		}
+		start = hunkEnd
+	}
+	return diff.String()
+}
+
+// hunkRange returns the range of lines of a hunk. Lines are numbered from 1, and empty ranges
+// start at the line before them
+func hunkRange(linesBefore, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", linesBefore)
+	}
+	return fmt.Sprintf("%d,%d", linesBefore+1, count)
+}
+
+// diffLines returns the operations that transform the first lines into the second ones,
+// keeping the longest common subsequence unchanged
+func diffLines(from, to []string) []diffOp {
+	// lcs[i][j] is the length of the longest common subsequence of from[i:] and to[j:]
+	lcs := make([][]int, len(from)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(to)+1)
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = maxInt(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, len(from)+len(to))
+	i, j := 0, 0
+	for i < len(from) && j < len(to) {
+		switch {
+		case from[i] == to[j]:
+			ops = append(ops, diffOp{' ', from[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{'-', from[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', to[j]})
+			j++
+		}
+	}
+	for ; i < len(from); i++ {
+		ops = append(ops, diffOp{'-', from[i]})
+	}
+	for ; j < len(to); j++ {
+		ops = append(ops, diffOp{'+', to[j]})
+	}
+	return ops
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
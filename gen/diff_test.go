@@ -0,0 +1,49 @@
+package gen
+
+import "testing"
+
+type unifiedDiffTestCase struct {
+	name         string
+	from         string
+	to           string
+	expectedDiff string
+}
+
+var unifiedDiffTestCases = []unifiedDiffTestCase{
+	{
+		name:         "Equal texts",
+		from:         "a\nb\n",
+		to:           "a\nb\n",
+		expectedDiff: "",
+	}, {
+		name:         "Added text",
+		from:         "",
+		to:           "a\nb\n",
+		expectedDiff: "--- from\n+++ to\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+	}, {
+		name:         "Removed text",
+		from:         "a\nb\n",
+		to:           "",
+		expectedDiff: "--- from\n+++ to\n@@ -1,2 +0,0 @@\n-a\n-b\n",
+	}, {
+		name:         "Modified line with context",
+		from:         "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+		to:           "1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+		expectedDiff: "--- from\n+++ to\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+	}, {
+		name: "Distant changes in different hunks",
+		from: "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+		to:   "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n",
+		expectedDiff: "--- from\n+++ to\n@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n" +
+			"@@ -10,3 +10,4 @@\n 10\n 11\n 12\n+13\n",
+	},
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	for _, testCase := range unifiedDiffTestCases {
+		diff := unifiedDiff("from", "to", testCase.from, testCase.to)
+		if diff != testCase.expectedDiff {
+			t.Errorf("Test %q: Expected diff:\n%s\ngot:\n%s", testCase.name, testCase.expectedDiff, diff)
+		}
+	}
+}
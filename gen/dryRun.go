@@ -0,0 +1,155 @@
+package gen
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/juju/errors"
+)
+
+//go:generate enumer -type=FileChangeKind
+
+// FileChangeKind is how a file of the output directory would change with the generation
+type FileChangeKind int
+
+const (
+	AddedFile FileChangeKind = iota
+	RemovedFile
+	ModifiedFile
+)
+
+// FileChange is a change the generation would make in the output directory
+type FileChange struct {
+	// Path is relative to the output directory
+	Path string
+	Kind FileChangeKind
+	// Diff contains the changes of the file in unified format
+	Diff string
+}
+
+// creationTimeRegexp matches the creation time in the header of the generated files, which
+// is ignored to tell whether a file would change
+var creationTimeRegexp = regexp.MustCompile(`(?m)^//\s*Created on .*$`)
+
+// Changes returns the changes found by the last dry run generation
+func (g *Generator) Changes() []FileChange {
+	return g.changes
+}
+
+// generateDryRun generates the files in a temporary directory and compares them with the ones in the
+// output directory, which is not modified. ErrOutputChanged is returned if they are different
+func (g *Generator) generateDryRun() error {
+	tmpDir, err := ioutil.TempDir("", "sdkgen")
+	if err != nil {
+		return errors.Annotate(err, "when creating the dry run directory")
+	}
+	defer os.RemoveAll(tmpDir)
+
+	outputDir := g.config.OutputDir
+	g.config.OutputDir = tmpDir
+	err = g.generate()
+	g.config.OutputDir = outputDir
+	if err != nil {
+		return err
+	}
+
+	changes, err := compareDirs(tmpDir, outputDir, g.config.APIName, !g.config.KeepStaleFiles)
+	if err != nil {
+		return errors.Annotate(err, "when comparing the generated files with the output directory")
+	}
+	// The files only created once are never overwritten
+	g.changes = nil
+	for _, change := range changes {
+		if _, createOnly := g.createOnlyFiles[change.Path]; !createOnly || change.Kind != ModifiedFile {
+			g.changes = append(g.changes, change)
+		}
+	}
+	if len(g.changes) > 0 {
+		return errors.Annotatef(ErrOutputChanged, "%d files would change in %q", len(g.changes), outputDir)
+	}
+	return nil
+}
+
+// compareDirs returns the changes from the files in the old directory to the ones in the new one.
+// Only the files in the API directory are compared, and only the ones in the manifest of the old one
+// can be removed
+func compareDirs(newDir, oldDir, apiName string, removeStaleFiles bool) ([]FileChange, error) {
+	newFiles, err := readDirFiles(newDir, apiName)
+	if err != nil {
+		return nil, err
+	}
+	oldFiles, err := readDirFiles(oldDir, apiName)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []FileChange
+	for filePath, newContent := range newFiles {
+		oldContent, found := oldFiles[filePath]
+		switch {
+		case !found:
+			changes = append(changes, FileChange{
+				Path: filePath,
+				Kind: AddedFile,
+				Diff: unifiedDiff("/dev/null", "b/"+filePath, "", newContent),
+			})
+		case creationTimeRegexp.ReplaceAllString(oldContent, "") != creationTimeRegexp.ReplaceAllString(newContent, ""):
+			changes = append(changes, FileChange{
+				Path: filePath,
+				Kind: ModifiedFile,
+				Diff: unifiedDiff("a/"+filePath, "b/"+filePath, oldContent, newContent),
+			})
+		}
+	}
+	manifestFiles, err := readManifest(filepath.Join(oldDir, apiName))
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range manifestFiles {
+		filePath := filepath.ToSlash(filepath.Join(apiName, file))
+		oldContent, found := oldFiles[filePath]
+		if _, generated := newFiles[filePath]; found && !generated && removeStaleFiles {
+			changes = append(changes, FileChange{
+				Path: filePath,
+				Kind: RemovedFile,
+				Diff: unifiedDiff("a/"+filePath, "/dev/null", oldContent, ""),
+			})
+		}
+	}
+	sort.Sort(fileChangesByPath(changes))
+	return changes, nil
+}
+
+// readDirFiles returns the content of the files in the API directory per path relative to the base directory,
+// except the manifest. There are no files if the API directory doesn't exist
+func readDirFiles(baseDir, apiName string) (map[string]string, error) {
+	files := map[string]string{}
+	err := filepath.Walk(filepath.Join(baseDir, apiName), func(filePath string, info os.FileInfo, err error) error {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil || info.IsDir() || info.Name() == manifestFileName {
+			return err
+		}
+		content, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(baseDir, filePath)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(relPath)] = string(content)
+		return nil
+	})
+	return files, errors.Trace(err)
+}
+
+type fileChangesByPath []FileChange
+
+func (c fileChangesByPath) Len() int           { return len(c) }
+func (c fileChangesByPath) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
+func (c fileChangesByPath) Less(i, j int) bool { return c[i].Path < c[j].Path }
@@ -0,0 +1,131 @@
+package gen
+
+import (
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/juju/errors"
+)
+
+const enumValuesSeparator = "|"
+
+// unknownEnumValue is the value every generated enum has for the strings not declared
+const unknownEnumValue = "Unknown"
+
+type enumInfo struct {
+	Name         string
+	OriginalName string
+	Values       []string
+}
+
+func newEnumInfo(name string) *enumInfo {
+	return &enumInfo{
+		Name:         name,
+		OriginalName: name,
+	}
+}
+
+// mergedValues returns the values of the enum with the new ones added, keeping the declaration order.
+// The values must be different identifiers in the generated code, and none of them can be the unknown value
+func (ei *enumInfo) mergedValues(values []string) ([]string, error) {
+	merged := append([]string(nil), ei.Values...)
+	for _, value := range values {
+		if containsString(merged, value) {
+			continue
+		}
+		identifier := enumValueIdentifier(value)
+		switch identifier {
+		case "":
+			return nil, errors.Annotatef(ErrInvalidEnum, "value %q of enum %q is not a valid identifier", value, ei.Name)
+		case unknownEnumValue:
+			return nil, errors.Annotatef(ErrInvalidEnum, "value %q of enum %q is reserved for the unknown values", value, ei.Name)
+		}
+		for _, other := range merged {
+			if enumValueIdentifier(other) == identifier {
+				return nil, errors.Annotatef(ErrInvalidEnum, "values %q and %q of enum %q are the same identifier %q", other, value, ei.Name, identifier)
+			}
+		}
+		merged = append(merged, value)
+	}
+	return merged, nil
+}
+
+// enumValueIdentifier returns the name of the enum value in the generated code, after the name of the enum
+func enumValueIdentifier(value string) string {
+	identifier := camelCase(value)
+	if identifier == "" {
+		return ""
+	}
+	return strings.ToUpper(identifier[:1]) + identifier[1:]
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func enumValuesFromSpec(spec string) []string {
+	var values []string
+	for _, value := range strings.Split(spec, enumValuesSeparator) {
+		value = strings.TrimSpace(value)
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// setPropertyEnum sets the enum declared in the property attributes as the property type. The enum is
+// created or updated with registerPropertyEnum once the property is stored in the model
+func (g *Generator) setPropertyEnum(mInfo *modelInfo, prop *property, propVal interface{}) error {
+	if prop.IsArray || prop.IsMap {
+		return errors.Annotatef(ErrInvalidEnum, "property %q must be a single value", prop.location)
+	}
+
+	enumName := prop.attributes.forcedType
+	if enumName == "" {
+		enumName = camelCase(mInfo.Name + "_" + prop.Name)
+	}
+	eInfo, found := g.enumsInfo[enumName]
+	if !found {
+		eInfo = newEnumInfo(enumName)
+	}
+	values, err := eInfo.mergedValues(prop.attributes.enumValues)
+	if err != nil {
+		return errors.Annotatef(err, "in property %q", prop.location)
+	}
+
+	if propVal != nil {
+		value, isString := propVal.(string)
+		if !isString {
+			return errors.Annotatef(ErrInvalidEnum, "property %q must be a string", prop.location)
+		}
+		if !containsString(values, value) {
+			return errors.Annotatef(ErrInvalidEnum, "value %q of property %q is not one of %q", value, prop.location, values)
+		}
+	}
+
+	prop.Type = enumName
+	prop.TypeLabel = enumName
+	prop.DateFormat = ""
+	prop.DateInString = false
+	prop.Enum = eInfo
+	prop.isModel = false
+	return nil
+}
+
+// registerPropertyEnum adds the values declared in the property to its enum and makes the model depend on it
+func (g *Generator) registerPropertyEnum(mInfo *modelInfo, prop property) {
+	eInfo := prop.Enum
+	if _, found := g.enumsInfo[eInfo.Name]; found && len(eInfo.Values) != len(prop.attributes.enumValues) {
+		log.Warnf("enum %q is declared with different values. All of them are used", eInfo.Name)
+	}
+	g.enumsInfo[eInfo.Name] = eInfo
+	// The values were validated when setting the enum of the property
+	eInfo.Values, _ = eInfo.mergedValues(prop.attributes.enumValues)
+	mInfo.EnumDependencies[eInfo] = struct{}{}
+}
@@ -0,0 +1,145 @@
+package gen
+
+import (
+	"testing"
+
+	"github.com/alvaroloes/sdkgen/parser"
+	"github.com/alvaroloes/sdkgen/tests"
+	"github.com/kr/pretty"
+)
+
+type enumsTestCase struct {
+	name          string
+	api           *parser.API
+	expectedEnums map[string][]string
+	expectedError error
+}
+
+var enumsTestCases = []enumsTestCase{
+	{
+		name: "Enums declared in properties",
+		api: &parser.API{
+			Endpoints: []parser.Endpoint{
+				{
+					Method:    parser.GET,
+					URL:       tests.MustParseURL("https://www.alvarloes.com/posts"),
+					Resources: []parser.Resource{{Name: "posts"}},
+					ResponseBody: []interface{}{
+						map[string]interface{}{
+							"status: enum = draft | published":               "draft",
+							"kind: type = postKind; enum = text|image":       "text",
+							"visibility: type = postKind; enum = text|video": nil,
+						},
+						map[string]interface{}{"status": "published", "kind": "image"},
+					},
+				},
+			},
+		},
+		expectedEnums: map[string][]string{
+			"postStatus": {"draft", "published"},
+			"postKind":   {"text", "image", "video"},
+		},
+	}, {
+		name: "Value not declared in the enum",
+		api: &parser.API{
+			Endpoints: []parser.Endpoint{
+				{
+					Method:    parser.GET,
+					URL:       tests.MustParseURL("https://www.alvarloes.com/posts"),
+					Resources: []parser.Resource{{Name: "posts"}},
+					ResponseBody: []interface{}{
+						map[string]interface{}{"status: enum = draft|published": "draft"},
+						map[string]interface{}{"status": "archived"},
+					},
+				},
+			},
+		},
+		expectedError: ErrInvalidEnum,
+	}, {
+		name: "Value reserved for the unknown values",
+		api: &parser.API{
+			Endpoints: []parser.Endpoint{
+				{
+					Method:       parser.GET,
+					URL:          tests.MustParseURL("https://www.alvarloes.com/posts"),
+					Resources:    []parser.Resource{{Name: "posts"}},
+					ResponseBody: map[string]interface{}{"status: enum = draft|unknown": "draft"},
+				},
+			},
+		},
+		expectedError: ErrInvalidEnum,
+	}, {
+		name: "Values with the same identifier",
+		api: &parser.API{
+			Endpoints: []parser.Endpoint{
+				{
+					Method:       parser.GET,
+					URL:          tests.MustParseURL("https://www.alvarloes.com/posts"),
+					Resources:    []parser.Resource{{Name: "posts"}},
+					ResponseBody: map[string]interface{}{"status: enum = in-progress|in_progress": "in-progress"},
+				},
+			},
+		},
+		expectedError: ErrInvalidEnum,
+	}, {
+		name: "Enum of a property losing a type conflict",
+		api: &parser.API{
+			Endpoints: []parser.Endpoint{
+				{
+					Method:    parser.GET,
+					URL:       tests.MustParseURL("https://www.alvarloes.com/posts"),
+					Resources: []parser.Resource{{Name: "posts"}},
+					ResponseBody: []interface{}{
+						map[string]interface{}{
+							"status: enum = draft|published":     "draft",
+							"kind: type = postKind; enum = text": "text",
+						},
+						map[string]interface{}{"kind: type = otherKind; enum = image": "image"},
+					},
+				},
+			},
+		},
+		expectedEnums: map[string][]string{
+			"postStatus": {"draft", "published"},
+			"postKind":   {"text"},
+		},
+	}, {
+		name: "Enum declared in a later example",
+		api: &parser.API{
+			Endpoints: []parser.Endpoint{
+				{
+					Method:    parser.GET,
+					URL:       tests.MustParseURL("https://www.alvarloes.com/posts"),
+					Resources: []parser.Resource{{Name: "posts"}},
+					ResponseBody: []interface{}{
+						map[string]interface{}{"status": "draft"},
+						map[string]interface{}{"status: enum = draft|published": "published"},
+					},
+				},
+			},
+		},
+		expectedEnums: map[string][]string{
+			"postStatus": {"draft", "published"},
+		},
+	},
+}
+
+func TestEnums(t *testing.T) {
+	for _, testCase := range enumsTestCases {
+		gen, ok := extractTestModelsInfo(t, testCase.name, testCase.api, Config{}, testCase.expectedError)
+		if !ok {
+			continue
+		}
+
+		enumValues := map[string][]string{}
+		for name, eInfo := range gen.enumsInfo {
+			enumValues[name] = eInfo.Values
+		}
+		if diffs := pretty.Diff(testCase.expectedEnums, enumValues); len(diffs) > 0 {
+			t.Errorf("Test %q: Didn't get the expected enums. Differences are:\n%v", testCase.name, tests.FormattedDiff(diffs))
+		}
+		if status := gen.modelsInfo["post"].Properties["status"]; status.Enum == nil || status.Type != "postStatus" {
+			t.Errorf("Test %q: Expected the status property to be an enum, got: %v", testCase.name, status)
+		}
+	}
+}
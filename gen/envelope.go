@@ -0,0 +1,82 @@
+package gen
+
+import (
+	"reflect"
+
+	"github.com/juju/errors"
+)
+
+// envelopeNone disables the envelope of the config in an endpoint: `<- envelope = none`
+const envelopeNone = "none"
+
+// envelope is the object wrapping the payload of a response, like {"data": ..., "meta": ...}.
+// The models are extracted from the payload, and the generated services unwrap it
+type envelope struct {
+	DataKey string
+	// MetaKey is the key of the additional information returned along with the payload, if any
+	MetaKey string
+}
+
+// responseEnvelope returns the envelope of the response declared in its attributes or in the config.
+// It is nil for responses without body or whose content is not JSON
+func (g *Generator) responseEnvelope(attrs modelAttributes, body interface{}) (*envelope, error) {
+	env := &envelope{
+		DataKey: g.config.ResponseEnvelopeKey,
+		MetaKey: g.config.ResponseEnvelopeMetaKey,
+	}
+	if attrs.envelope != "" {
+		env.DataKey = attrs.envelope
+	}
+	if attrs.envelopeMeta != "" {
+		env.MetaKey = attrs.envelopeMeta
+	}
+	if env.DataKey == "" || env.DataKey == envelopeNone {
+		return nil, nil
+	}
+	if body == nil || (attrs.content != "" && attrs.content != contentJSON) {
+		return nil, nil
+	}
+
+	if reflect.TypeOf(body).Kind() != reflect.Map {
+		return nil, errors.Annotatef(ErrInvalidEnvelope, "the response must be an object to be unwrapped")
+	}
+	if _, found := env.dataSpec(body); !found {
+		return nil, errors.Annotatef(ErrInvalidEnvelope, "the response has no %q key", env.DataKey)
+	}
+	return env, nil
+}
+
+// dataSpec returns the property spec of the payload in the response body, which can declare attributes
+// like any other property: `"data: type = post": {...}`
+func (env *envelope) dataSpec(body interface{}) (string, bool) {
+	for _, propSpec := range sortedKeys(body.(map[string]interface{})) {
+		if newPropertyAttributes(propSpec).name == env.DataKey {
+			return propSpec, true
+		}
+	}
+	return "", false
+}
+
+// payload returns the value wrapped by the envelope in the response body
+func (env *envelope) payload(body interface{}) interface{} {
+	propSpec, _ := env.dataSpec(body)
+	return body.(map[string]interface{})[propSpec]
+}
+
+// payloadAttributes returns the attributes of the response model with the ones declared in the payload property
+func (env *envelope) payloadAttributes(body interface{}, attrs modelAttributes) modelAttributes {
+	propSpec, _ := env.dataSpec(body)
+	dataAttrs := newPropertyAttributes(propSpec)
+	if dataAttrs.forcedType != "" {
+		attrs.modelType = dataAttrs.forcedType
+	}
+	if dataAttrs.extends != "" {
+		attrs.extends = dataAttrs.extends
+	}
+	if dataAttrs.discriminator != "" {
+		attrs.discriminator = dataAttrs.discriminator
+	}
+	attrs.forceAsMap = attrs.forceAsMap || dataAttrs.forceAsMap
+	attrs.raw = attrs.raw || dataAttrs.raw
+	return attrs
+}
@@ -0,0 +1,113 @@
+package gen
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/alvaroloes/sdkgen/parser"
+	"github.com/alvaroloes/sdkgen/tests"
+	"github.com/kr/pretty"
+)
+
+type envelopesTestCase struct {
+	name                 string
+	config               Config
+	responseSpec         string
+	responseBody         interface{}
+	expectedEnvelope     *envelope
+	expectedResponseKind ResponseKind
+	// expectedModel is the model of the payload. It is "post" if empty
+	expectedModel      string
+	expectedProperties []string
+	expectedError      error
+}
+
+var envelopesTestCases = []envelopesTestCase{
+	{
+		name:                 "No envelope",
+		responseBody:         map[string]interface{}{"data": map[string]interface{}{"id": "1"}},
+		expectedResponseKind: ModelResponse,
+		expectedProperties:   []string{"data"},
+	}, {
+		name:                 "Envelope in the config",
+		config:               Config{ResponseEnvelopeKey: "data", ResponseEnvelopeMetaKey: "meta"},
+		responseBody:         map[string]interface{}{"data": []interface{}{map[string]interface{}{"id": "1"}}, "meta": map[string]interface{}{"total": json.Number("1")}},
+		expectedEnvelope:     &envelope{DataKey: "data", MetaKey: "meta"},
+		expectedResponseKind: ArrayResponse,
+		expectedProperties:   []string{"id"},
+	}, {
+		name:                 "Envelope in the endpoint",
+		responseSpec:         "envelope = result",
+		responseBody:         map[string]interface{}{"result": map[string]interface{}{"id": "1"}},
+		expectedEnvelope:     &envelope{DataKey: "result"},
+		expectedResponseKind: ModelResponse,
+		expectedProperties:   []string{"id"},
+	}, {
+		name:                 "Envelope disabled in the endpoint",
+		config:               Config{ResponseEnvelopeKey: "data"},
+		responseSpec:         "envelope = none",
+		responseBody:         map[string]interface{}{"id": "1"},
+		expectedResponseKind: ModelResponse,
+		expectedProperties:   []string{"id"},
+	}, {
+		name:                 "Envelope data with attributes",
+		config:               Config{ResponseEnvelopeKey: "data"},
+		responseBody:         map[string]interface{}{"data: type = article": []interface{}{map[string]interface{}{"id": "1"}}},
+		expectedEnvelope:     &envelope{DataKey: "data"},
+		expectedResponseKind: ArrayResponse,
+		expectedModel:        "article",
+		expectedProperties:   []string{"id"},
+	}, {
+		name:          "Missing payload",
+		config:        Config{ResponseEnvelopeKey: "data"},
+		responseBody:  map[string]interface{}{"id": "1"},
+		expectedError: ErrInvalidEnvelope,
+	}, {
+		name:          "Response not wrapped in an object",
+		config:        Config{ResponseEnvelopeKey: "data"},
+		responseBody:  []interface{}{map[string]interface{}{"id": "1"}},
+		expectedError: ErrInvalidEnvelope,
+	},
+}
+
+func TestResponseEnvelopes(t *testing.T) {
+	for _, testCase := range envelopesTestCases {
+		api := &parser.API{Endpoints: []parser.Endpoint{{
+			Method:       parser.GET,
+			URL:          tests.MustParseURL("https://www.alvarloes.com/posts"),
+			Resources:    []parser.Resource{{Name: "posts"}},
+			ResponseSpec: testCase.responseSpec,
+			ResponseBody: testCase.responseBody,
+		}}}
+		gen, ok := extractTestModelsInfo(t, testCase.name, api, testCase.config, testCase.expectedError)
+		if !ok {
+			continue
+		}
+
+		epi := gen.modelsInfo["post"].EndpointsInfo[0]
+		if diffs := pretty.Diff(testCase.expectedEnvelope, epi.Envelope); len(diffs) > 0 {
+			t.Errorf("Test %q: Didn't get the expected envelope. Differences are:\n%v", testCase.name, tests.FormattedDiff(diffs))
+		}
+		if epi.ResponseKind != testCase.expectedResponseKind {
+			t.Errorf("Test %q: Expected response kind %s, got: %s", testCase.name, testCase.expectedResponseKind, epi.ResponseKind)
+		}
+		expectedModel := testCase.expectedModel
+		if expectedModel == "" {
+			expectedModel = "post"
+		}
+		if epi.ResponseModel.Name != expectedModel {
+			t.Errorf("Test %q: Expected the payload in model %q, got: %q", testCase.name, expectedModel, epi.ResponseModel.Name)
+			continue
+		}
+		mInfo := epi.ResponseModel
+		properties := []string{}
+		for propName := range mInfo.Properties {
+			properties = append(properties, propName)
+		}
+		sort.Strings(properties)
+		if diffs := pretty.Diff(testCase.expectedProperties, properties); len(diffs) > 0 {
+			t.Errorf("Test %q: Didn't get the expected properties. Differences are:\n%v", testCase.name, tests.FormattedDiff(diffs))
+		}
+	}
+}
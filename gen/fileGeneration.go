@@ -0,0 +1,70 @@
+package gen
+
+import (
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/juju/errors"
+)
+
+// fileJob is the generation of a file applying a template
+type fileJob struct {
+	filePath    string
+	tpl         *template.Template
+	data        templateData
+	description string
+	// createOnly files are only generated if they don't exist, so they are never overwritten
+	createOnly bool
+}
+
+// generateFiles runs the jobs in a pool of workers. The first error cancels the jobs not started yet,
+// and all the errors of the started ones are returned together
+func (g *Generator) generateFiles(jobs []fileJob) error {
+	workers := g.config.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	pending := make(chan fileJob)
+	cancel := make(chan struct{})
+	var cancelOnce sync.Once
+	var errorsMutex sync.Mutex
+	var errorMessages []string
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range pending {
+				if err := generateFile(job.filePath, job.tpl, job.data, job.createOnly); err != nil {
+					errorsMutex.Lock()
+					errorMessages = append(errorMessages, errors.Annotatef(err, "when generating %s", job.description).Error())
+					errorsMutex.Unlock()
+					cancelOnce.Do(func() { close(cancel) })
+				}
+			}
+		}()
+	}
+
+feeding:
+	for _, job := range jobs {
+		select {
+		case pending <- job:
+		case <-cancel:
+			break feeding
+		}
+	}
+	close(pending)
+	wg.Wait()
+
+	if len(errorMessages) > 0 {
+		// The errors are sorted as they are found in any order
+		sort.Strings(errorMessages)
+		return errors.Annotate(ErrFileGeneration, strings.Join(errorMessages, "\n"))
+	}
+	return nil
+}
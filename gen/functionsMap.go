@@ -26,15 +26,7 @@ var funcMap = template.FuncMap{
 	"singular": func(s string) string {
 		return inflection.Singular(s)
 	},
-	"camelCase": func(s string) string {
-		chunks := camelCaseRegexp.FindAllString(s, -1)
-		for idx, val := range chunks {
-			if idx > 0 {
-				chunks[idx] = strings.Title(val)
-			}
-		}
-		return strings.Join(chunks, "")
-	},
+	"camelCase": camelCase,
 	"dict": func(values ...interface{}) (map[string]interface{}, error) {
 		if len(values)%2 != 0 {
 			return nil, errors.New("invalid dict call")
@@ -50,3 +42,13 @@ var funcMap = template.FuncMap{
 		return dict, nil
 	},
 }
+
+func camelCase(s string) string {
+	chunks := camelCaseRegexp.FindAllString(s, -1)
+	for idx, val := range chunks {
+		if idx > 0 {
+			chunks[idx] = strings.Title(val)
+		}
+	}
+	return strings.Join(chunks, "")
+}
@@ -1,7 +1,7 @@
 package gen
 
 import (
-	"net/url"
+	"fmt"
 	"os"
 	"path"
 	"reflect"
@@ -11,8 +11,8 @@ import (
 
 	"path/filepath"
 
+	log "github.com/Sirupsen/logrus"
 	"github.com/alvaroloes/sdkgen/parser"
-	"github.com/jinzhu/inflection"
 	"github.com/juju/errors"
 )
 
@@ -22,7 +22,18 @@ var (
 	ErrLangNotSupported      = errors.New("language not supported")
 	ErrMultipleAuthEndpoints = errors.New("more than one authentication endpoint is not supported")
 	ErrInvalidAuthResponse   = errors.Errorf("invalid response for the authentication endpoint. Only %s is supported", ModelResponse)
-	ErrNullPropertyValue     = errors.New("null property values are not allowed")
+	ErrPropertyTypeConflict  = errors.New("the same property has different types in the spec")
+	ErrInvalidDateFormat     = errors.New("invalid date format")
+	ErrInvalidEnum           = errors.New("invalid enum property")
+	ErrInvalidInheritance    = errors.New("invalid model inheritance")
+	ErrInvalidDiscriminator  = errors.New("invalid discriminator")
+	ErrModelNameCollision    = errors.New("different models have the same name in the spec")
+	ErrInvalidSegmentParam   = errors.New("invalid segment parameter")
+	ErrInvalidContent        = errors.New("invalid body content")
+	ErrInvalidPagination     = errors.New("invalid pagination")
+	ErrInvalidEnvelope       = errors.New("invalid response envelope")
+	ErrFileGeneration        = errors.New("some files couldn't be generated")
+	ErrOutputChanged         = errors.New("the generated files differ from the ones in the output directory")
 )
 
 //go:generate enumer -type=Language
@@ -40,11 +51,13 @@ const (
 	commonTemplatesPath            = "common"
 	modelTemplatePath              = "model"
 	serviceTemplatePath            = "service"
+	extensionTemplatePath          = "extension"
 	templateExt                    = ".tpl"
 	fileNameModelNameInterpolation = "--ModelName--"
 	fileNameAPINameInterpolation   = "--APIName--"
 	fileNameAPIPrefixInterpolation = "--APIPrefix--"
 	dirPermissions                 = 0777
+	filePermissions                = 0666
 )
 
 // Config contains the needed configuration for the generator
@@ -54,6 +67,38 @@ type Config struct {
 	ServicesRelPath string
 	APIName         string
 	APIPrefix       string
+	// ExtensionsRelPath is where the model extensions are generated (ObjC categories). They are created
+	// only if they don't exist, so the code added to them is kept. They are not generated if it's empty
+	ExtensionsRelPath string
+	// StrictPropertyTypes makes the generation fail when a property is found
+	// with different types. Otherwise the first type found is used and a warning is logged
+	StrictPropertyTypes bool
+	// ModelNamesStrategy is how the nested models with the same name but different shapes are named
+	ModelNamesStrategy ModelNamesStrategy
+	// ModelNames renames the models. The keys are the model names or "parentModel.property" to rename
+	// only the model of a property
+	ModelNames map[string]string
+	// KeepFormatSuffixes keeps the format suffixes (.json) of the endpoints in the request paths.
+	// They are never part of the model and service names
+	KeepFormatSuffixes bool
+	// ResponseEnvelopeKey is the key of the payload in the responses wrapped in an envelope,
+	// like "data" in {"data": ..., "meta": ...}. Endpoints can override it with `<- envelope = key`
+	ResponseEnvelopeKey string
+	// ResponseEnvelopeMetaKey is the key of the information returned along with the payload
+	// by the services. Endpoints can override it with `<- meta = key`
+	ResponseEnvelopeMetaKey string
+	// Timestamp pins the creation time written in the generated files. The current time is used if it is zero
+	Timestamp time.Time
+	// OmitTimestamp doesn't write the creation time in the generated files, so that they only change with the spec
+	OmitTimestamp bool
+	// Workers is the maximum number of files generated concurrently. The number of CPUs is used if it is not positive
+	Workers int
+	// DryRun doesn't write any file. The changes the generation would make in the output directory
+	// are available with Changes, and ErrOutputChanged is returned if there is any
+	DryRun bool
+	// KeepStaleFiles only reports the files generated by a previous run that are not generated anymore,
+	// instead of removing them. Files not created by the generator are never removed
+	KeepStaleFiles bool
 }
 
 type templateData struct {
@@ -61,12 +106,13 @@ type templateData struct {
 	API              *parser.API
 	CurrentModelInfo *modelInfo
 	AllModelsInfo    map[string]*modelInfo
+	AllEnumsInfo     map[string]*enumInfo
 	AuthInfo         *authInfo
 	CurrentTime      time.Time
 }
 
 type languageSpecificGenerator interface {
-	adaptModelsInfo(modelsInfo map[string]*modelInfo, api *parser.API, config Config)
+	adaptModelsInfo(modelsInfo map[string]*modelInfo, enumsInfo map[string]*enumInfo, api *parser.API, config Config)
 	funcMap() template.FuncMap
 }
 
@@ -75,19 +121,48 @@ type Generator struct {
 	gen        languageSpecificGenerator
 	api        *parser.API
 	modelsInfo map[string]*modelInfo // Contains processed information to generate the models
+	enumsInfo  map[string]*enumInfo  // Contains the enums declared in the model properties
 	authInfo   *authInfo
 	config     Config
 	tplDir     string
+
+	propertyConflicts   []propertyTypeConflict
+	modelNameCollisions []modelNameCollision
+	modelOrigins        map[string][]*modelOrigin // Per model name, in the order they are found
+	prefixedModelNames  map[string]struct{}
+	creationTime        time.Time
+	changes             []FileChange
+	createOnlyFiles     map[string]struct{} // Paths relative to the output directory, slash separated
+
+	// Where the models and properties are found in the spec
+	responseEnvelopes map[int]*envelope // Per endpoint index
+	propertyPositions map[SpecPosition]propertyRef
+	modelPositions    map[*modelInfo]SpecPosition
 }
 
+// Generate generates the SDK files in the output directory, or only compares them
+// with the existing ones in dry run mode
 func (g *Generator) Generate() error {
+	if g.config.DryRun {
+		return g.generateDryRun()
+	}
+	return g.generate()
+}
+
+func (g *Generator) generate() error {
 	// Extract the models info
 	err := g.extractModelsInfo()
 	if err != nil {
 		return errors.Trace(err)
 	}
 	// Adapt them to the specific language
-	g.gen.adaptModelsInfo(g.modelsInfo, g.api, g.config)
+	g.gen.adaptModelsInfo(g.modelsInfo, g.enumsInfo, g.api, g.config)
+
+	// All the files have the same creation time
+	g.creationTime = g.config.Timestamp
+	if g.creationTime.IsZero() {
+		g.creationTime = time.Now()
+	}
 
 	// Parse the base templates that contains common definitions
 	baseTplsGlob := path.Join(g.tplDir, commonTemplatesPath, "*"+templateExt)
@@ -109,6 +184,10 @@ func (g *Generator) Generate() error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	extensionTplFileNames, extensionTpls, err := g.parseTemplates(path.Join(g.tplDir, extensionTemplatePath, "*"+templateExt), baseTpls)
+	if err != nil {
+		return errors.Trace(err)
+	}
 
 	// Create the needed directories
 	apiDir := path.Join(g.config.OutputDir, g.config.APIName)
@@ -120,26 +199,41 @@ func (g *Generator) Generate() error {
 	if err := os.MkdirAll(servicesDir, dirPermissions); err != nil {
 		return errors.Annotatef(err, "when creating service directory")
 	}
+	extensionsDir := path.Join(apiDir, g.config.ExtensionsRelPath)
+	if g.config.ExtensionsRelPath != "" {
+		if err := os.MkdirAll(extensionsDir, dirPermissions); err != nil {
+			return errors.Annotatef(err, "when creating extension directory")
+		}
+	}
 
 	// Generate the SDK files applying the templates
-	err = g.generateGeneralFiles(generalTplFileNames, generalTpls, apiDir)
-	if err != nil {
-		return errors.Annotate(err, "when generating API files")
+	jobs := g.generalFileJobs(generalTplFileNames, generalTpls, apiDir)
+	jobs = append(jobs, g.perModelFileJobs(modelTplFileNames, modelTpls, modelsDir, "model", func(modelInfo *modelInfo) bool {
+		return !modelInfo.HasModelFile()
+	})...)
+	jobs = append(jobs, g.perModelFileJobs(serviceTplFileNames, serviceTpls, servicesDir, "service", func(modelInfo *modelInfo) bool {
+		return len(modelInfo.EndpointsInfo) == 0
+	})...)
+	if g.config.ExtensionsRelPath != "" {
+		extensionJobs := g.perModelFileJobs(extensionTplFileNames, extensionTpls, extensionsDir, "extension", func(modelInfo *modelInfo) bool {
+			// Only the models imported by the API header are extended
+			return !modelInfo.HasModelFile()
+		})
+		for i := range extensionJobs {
+			extensionJobs[i].createOnly = true
+		}
+		jobs = append(jobs, extensionJobs...)
 	}
-	err = g.generatePerModelFiles(modelTplFileNames, modelTpls, modelsDir, func(modelInfo *modelInfo) bool {
-		return len(modelInfo.Properties) == 0
-	})
-	if err != nil {
-		return errors.Annotate(err, "when generating model files")
+	g.createOnlyFiles = map[string]struct{}{}
+	for _, job := range jobs {
+		if relPath, err := filepath.Rel(g.config.OutputDir, job.filePath); err == nil && job.createOnly {
+			g.createOnlyFiles[filepath.ToSlash(relPath)] = struct{}{}
+		}
 	}
-	err = g.generatePerModelFiles(serviceTplFileNames, serviceTpls, servicesDir, func(modelInfo *modelInfo) bool {
-		return len(modelInfo.EndpointsInfo) == 0
-	})
-	if err != nil {
-		return errors.Annotate(err, "when generating service files")
+	if err := g.generateFiles(jobs); err != nil {
+		return err
 	}
-
-	return nil
+	return g.updateManifest(apiDir, jobs)
 }
 
 func (g *Generator) parseTemplates(pathGlob string, baseTpl *template.Template) (fileNames []string, templates *template.Template, err error) {
@@ -154,10 +248,10 @@ func (g *Generator) parseTemplates(pathGlob string, baseTpl *template.Template)
 	return
 }
 
-func (g *Generator) generateGeneralFiles(templateFileNames []string, generalTpls *template.Template, apiDir string) error {
+func (g *Generator) generalFileJobs(templateFileNames []string, generalTpls *template.Template, apiDir string) []fileJob {
+	var jobs []fileJob
 	for _, tplFileName := range templateFileNames {
 		tplName := filepath.Base(tplFileName)
-		// TODO: Do this concurrently
 		// Get the name of the file, replacing some special strings in the template name
 		repl := strings.NewReplacer(
 			templateExt, "",
@@ -165,21 +259,25 @@ func (g *Generator) generateGeneralFiles(templateFileNames []string, generalTpls
 			fileNameAPIPrefixInterpolation, g.config.APIPrefix,
 		)
 		fileName := repl.Replace(tplName)
-		err := generateFile(path.Join(apiDir, fileName), generalTpls.Lookup(tplName), templateData{
-			Config:        g.config,
-			API:           g.api,
-			AllModelsInfo: g.modelsInfo,
-			CurrentTime:   time.Now(),
-			AuthInfo:      g.authInfo,
+		jobs = append(jobs, fileJob{
+			filePath:    path.Join(apiDir, fileName),
+			tpl:         generalTpls.Lookup(tplName),
+			description: fmt.Sprintf("API file %q", fileName),
+			data: templateData{
+				Config:        g.config,
+				API:           g.api,
+				AllModelsInfo: g.modelsInfo,
+				AllEnumsInfo:  g.enumsInfo,
+				CurrentTime:   g.creationTime,
+				AuthInfo:      g.authInfo,
+			},
 		})
-		if err != nil {
-			return errors.Annotatef(err, "when generating API file %q", fileName)
-		}
 	}
-	return nil
+	return jobs
 }
 
-func (g *Generator) generatePerModelFiles(templateFileNames []string, modelTpls *template.Template, modelsDir string, filter func(modelInfo *modelInfo) bool) error {
+func (g *Generator) perModelFileJobs(templateFileNames []string, modelTpls *template.Template, modelsDir, kind string, filter func(modelInfo *modelInfo) bool) []fileJob {
+	var jobs []fileJob
 	for _, tplFileName := range templateFileNames {
 		tplName := filepath.Base(tplFileName)
 		// Apply the templates to each model in the API
@@ -187,7 +285,6 @@ func (g *Generator) generatePerModelFiles(templateFileNames []string, modelTpls
 			if filter(modelInfo) {
 				continue
 			}
-			// TODO: Do this concurrently
 			repl := strings.NewReplacer(
 				templateExt, "",
 				fileNameModelNameInterpolation, modelInfo.Name,
@@ -195,24 +292,34 @@ func (g *Generator) generatePerModelFiles(templateFileNames []string, modelTpls
 				fileNameAPIPrefixInterpolation, g.config.APIPrefix,
 			)
 			fileName := repl.Replace(tplName)
-			err := generateFile(path.Join(modelsDir, fileName), modelTpls.Lookup(tplName), templateData{
-				Config:           g.config,
-				API:              g.api,
-				CurrentModelInfo: modelInfo,
-				AllModelsInfo:    g.modelsInfo,
-				AuthInfo:         g.authInfo,
-				CurrentTime:      time.Now(),
+			jobs = append(jobs, fileJob{
+				filePath:    path.Join(modelsDir, fileName),
+				tpl:         modelTpls.Lookup(tplName),
+				description: fmt.Sprintf("%s file %q of model %q", kind, fileName, modelInfo.Name),
+				data: templateData{
+					Config:           g.config,
+					API:              g.api,
+					CurrentModelInfo: modelInfo,
+					AllModelsInfo:    g.modelsInfo,
+					AllEnumsInfo:     g.enumsInfo,
+					AuthInfo:         g.authInfo,
+					CurrentTime:      g.creationTime,
+				},
 			})
-			if err != nil {
-				return errors.Annotatef(err, "when generating model or service %q", modelInfo.Name)
-			}
 		}
 	}
-	return nil
+	return jobs
 }
 
-func generateFile(filePath string, tpl *template.Template, data templateData) error {
-	file, err := os.Create(filePath)
+func generateFile(filePath string, tpl *template.Template, data templateData, createOnly bool) error {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if createOnly {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	}
+	file, err := os.OpenFile(filePath, flags, filePermissions)
+	if createOnly && os.IsExist(err) {
+		return nil
+	}
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -221,10 +328,48 @@ func generateFile(filePath string, tpl *template.Template, data templateData) er
 }
 
 func (g *Generator) extractModelsInfo() error {
+	if err := g.extractModels(); err != nil {
+		return err
+	}
+	if err := g.reportModelNameCollisions(); err != nil {
+		return err
+	}
+	return g.reportPropertyConflicts()
+}
+
+// extractModels extracts the models info, collecting the property conflicts and the model name
+// collisions without reporting them
+func (g *Generator) extractModels() error {
+	g.prefixedModelNames = map[string]struct{}{}
+	if err := g.mergeEndpointsModels(); err != nil {
+		return err
+	}
+	// Extract the models again, splitting the ones with colliding names, until there are no new collisions
+	for g.config.ModelNamesStrategy == ParentPrefixedModelNames && g.prefixCollidingModelNames() {
+		if err := g.mergeEndpointsModels(); err != nil {
+			return err
+		}
+	}
+
+	g.inferOptionalProperties()
+	return g.resolveModelsInheritance()
+}
+
+// mergeEndpointsModels extracts the models info from the request and response bodies of all the endpoints
+func (g *Generator) mergeEndpointsModels() error {
 	g.modelsInfo = map[string]*modelInfo{}
-	for _, endpoint := range g.api.Endpoints {
+	g.enumsInfo = map[string]*enumInfo{}
+	g.authInfo = nil
+	g.propertyConflicts = nil
+	g.modelNameCollisions = nil
+	g.modelOrigins = map[string][]*modelOrigin{}
+	g.responseEnvelopes = map[int]*envelope{}
+	g.propertyPositions = map[SpecPosition]propertyRef{}
+	g.modelPositions = map[*modelInfo]SpecPosition{}
+	for i, endpoint := range g.api.Endpoints {
 		// Extract the resource whose information is contained in this endpoint
 		mainResource := endpoint.Resources[len(endpoint.Resources)-1]
+		endpointAttrs := endpointAttributesFromSpec(endpoint.Spec)
 		resourceModelAttrs := modelAttributes{
 			modelType:  mainResource.Name,
 			forceAsMap: false,
@@ -237,21 +382,59 @@ func (g *Generator) extractModelsInfo() error {
 		if responseModelAttrs.modelType == "" {
 			responseModelAttrs.modelType = resourceModelAttrs.modelType
 		}
+		// The service only chooses the model owning the endpoint method, not the models of the bodies
+		if endpointAttrs.service != "" {
+			resourceModelAttrs.modelType = endpointAttrs.service
+		}
+
+		// The models are extracted from the payload of the enveloped responses
+		envelope, err := g.responseEnvelope(responseModelAttrs, endpoint.ResponseBody)
+		if err != nil {
+			return errors.Annotatef(err, "in endpoint %s %s", endpoint.Method, endpoint.URL.Path)
+		}
+		if envelope != nil {
+			responseModelAttrs = envelope.payloadAttributes(endpoint.ResponseBody, responseModelAttrs)
+			endpoint.ResponseBody = envelope.payload(endpoint.ResponseBody)
+			g.responseEnvelopes[i] = envelope
+		}
 
 		// Extract the endpoint info and set it to the corresponding model
-		epi := g.setEndpointInfo(resourceModelAttrs, requestModelAttrs, responseModelAttrs, endpoint)
+		epi, err := g.setEndpointInfo(endpointAttrs, resourceModelAttrs, requestModelAttrs, responseModelAttrs, envelope, endpoint)
+		if err != nil {
+			return errors.Annotatef(err, "in endpoint %s %s", endpoint.Method, endpoint.URL.Path)
+		}
 
 		// Merge the properties form the request and response bodies into
 		// the corresponding model
-		err := g.mergeModelProperties(requestModelAttrs.modelType, endpoint.RequestBody)
+		location := specLocation{
+			Method:   endpoint.Method,
+			URLPath:  epi.URLPath,
+			endpoint: i,
+		}
+		if err := g.setModelDiscriminator(requestModelAttrs.modelType, requestModelAttrs.discriminator); err != nil {
+			return err
+		}
+		if err := g.setModelDiscriminator(responseModelAttrs.modelType, responseModelAttrs.discriminator); err != nil {
+			return err
+		}
+
+		location.Body = requestBodyName
+		err = g.mergeModelProperties(requestModelAttrs.modelType, endpoint.RequestBody, location)
 		if err != nil {
 			return err
 		}
+		if err := g.setModelParent(requestModelAttrs.modelType, requestModelAttrs.extends); err != nil {
+			return err
+		}
 
-		err = g.mergeModelProperties(responseModelAttrs.modelType, endpoint.ResponseBody)
+		location.Body = responseBodyName
+		err = g.mergeModelProperties(responseModelAttrs.modelType, endpoint.ResponseBody, location)
 		if err != nil {
 			return err
 		}
+		if err := g.setModelParent(responseModelAttrs.modelType, responseModelAttrs.extends); err != nil {
+			return err
+		}
 
 		// Set the auth endpoint
 		if epi.Authenticates {
@@ -270,15 +453,44 @@ func (g *Generator) extractModelsInfo() error {
 			g.authInfo = authInfo
 		}
 	}
+	g.addModelShapeCollisions()
+	return nil
+}
+
+// inferOptionalProperties flags as optional the properties that are not present
+// in all the examples of its model
+func (g *Generator) inferOptionalProperties() {
+	for _, mInfo := range g.modelsInfo {
+		for propName, prop := range mInfo.Properties {
+			prop.IsOptional = prop.examplesCount < mInfo.examplesCount
+			mInfo.Properties[propName] = prop
+		}
+	}
+}
+
+func (g *Generator) reportPropertyConflicts() error {
+	if len(g.propertyConflicts) == 0 {
+		return nil
+	}
+	if g.config.StrictPropertyTypes {
+		return errors.Annotate(ErrPropertyTypeConflict, conflictsString(g.propertyConflicts))
+	}
+	for _, conflict := range g.propertyConflicts {
+		log.Warn(conflict.String() + ". Using the first one")
+	}
 	return nil
 }
 
-func (g *Generator) getURLPathForModels(url *url.URL) string {
+func (g *Generator) getURLPathForModels(endpoint parser.Endpoint, segmentParams []segmentParam) string {
 	//TODO: Strip version path when versioning is supported
-	return url.Path
+	urlPath := urlPathWithSegmentParams(endpoint.URL, segmentParams)
+	if g.config.KeepFormatSuffixes {
+		urlPath += endpoint.FormatSuffix
+	}
+	return urlPath
 }
 
-func (g *Generator) mergeModelProperties(modelName string, body interface{}) error {
+func (g *Generator) mergeModelProperties(modelName string, body interface{}, location specLocation) error {
 	if body == nil {
 		return nil
 	}
@@ -288,51 +500,184 @@ func (g *Generator) mergeModelProperties(modelName string, body interface{}) err
 	switch reflect.TypeOf(body).Kind() {
 	case reflect.Map:
 		props := body.(map[string]interface{})
-		for propSpec, val := range props {
-			if val == nil {
-				return errors.Annotatef(ErrNullPropertyValue, "while parsing %q", propSpec)
-			}
-			if err := g.mergeModelProperty(mInfo, propSpec, val); err != nil {
+		variant, err := g.variantModel(mInfo, props)
+		if err != nil {
+			return errors.Annotatef(err, "in %s", location)
+		}
+		g.setModelPosition(mInfo, location)
+		if variant != nil {
+			// The object belongs to the variant, so the base model is completed when resolving the inheritance
+			mInfo = variant
+			g.setModelPosition(mInfo, location)
+		}
+		mInfo.examplesCount++
+		if location.origin != "" {
+			g.addOriginProperties(modelName, location.origin, props)
+		}
+		// Sort the property specs so that the result doesn't depend on the map iteration order
+		for _, propSpec := range sortedKeys(props) {
+			if err := g.mergeModelProperty(mInfo, propSpec, props[propSpec], location); err != nil {
 				return err
 			}
 		}
 	case reflect.Array, reflect.Slice:
-		// Get the first object of the array and start again
+		// Merge all the objects of the array, so that all of them are checked
 		arrayVal := reflect.ValueOf(body)
-		if arrayVal.Len() == 0 {
-			return nil
+		for i := 0; i < arrayVal.Len(); i++ {
+			if err := g.mergeModelProperties(modelName, arrayVal.Index(i).Interface(), location.withIndex(i)); err != nil {
+				return err
+			}
 		}
-		return g.mergeModelProperties(modelName, arrayVal.Index(0).Interface())
 	}
 
 	// This means either an empty response or a non resource response. Ignore it
 	return nil
 }
 
-func (g *Generator) mergeModelProperty(mInfo *modelInfo, propSpec string, propVal interface{}) error {
-	prop := newProperty(propSpec, propVal)
-
-	_, found := mInfo.Properties[prop.Name]
+func (g *Generator) mergeModelProperty(mInfo *modelInfo, propSpec string, propVal interface{}, location specLocation) error {
+	attributes := newPropertyAttributes(propSpec)
+	if attributes.dateFormat != "" {
+		dateFormat, valid := dateFormatPerName[attributes.dateFormat]
+		if !valid {
+			return errors.Annotatef(ErrInvalidDateFormat, "%q in property %q", attributes.dateFormat, location.withProperty(attributes.name))
+		}
+		attributes.dateFormat = dateFormat
+	}
+	existingProp, found := mInfo.Properties[attributes.name]
 	if found {
-		// TODO: What to do now?. Either the old or the new one must have preference
-		// We could check if prop.Type's are equal. If not -> log a warning
-		// Right now old one has preference
+		// The attributes only need to be specified in one of the examples, not necessarily the first one
+		if existingProp.attributes.lacksAnyOf(attributes) {
+			typeDeclared := existingProp.attributes.forcedType == "" && attributes.forcedType != ""
+			existingProp = existingProp.withLaterAttributes(existingProp.attributes.inheritFrom(attributes))
+			if existingProp.isModel && typeDeclared {
+				existingProp.Type = g.nestedModelName(mInfo, existingProp)
+				existingProp.TypeLabel = existingProp.Type
+			}
+			if existingProp.attributes.enumValues != nil && existingProp.Enum == nil {
+				if err := g.setPropertyEnum(mInfo, &existingProp, nil); err != nil {
+					return err
+				}
+			}
+		}
+		attributes = attributes.inheritFrom(existingProp.attributes)
+	}
+	prop := newProperty(attributes, propVal, location.withProperty(attributes.name))
+	if prop.isModel {
+		prop.Type = g.nestedModelName(mInfo, prop)
+		prop.TypeLabel = prop.Type
+	}
+	g.propertyPositions[g.specPosition(prop.location)] = propertyRef{model: mInfo, name: prop.Name}
+	if attributes.enumValues != nil {
+		if err := g.setPropertyEnum(mInfo, &prop, propVal); err != nil {
+			return err
+		}
+	}
 
+	if found {
+		if existingProp.conflictsWith(prop) {
+			// The first one found has preference
+			if existingProp.location.origin != prop.location.origin {
+				// The objects come from different places, so they are probably different models
+				g.modelNameCollisions = append(g.modelNameCollisions, modelNameCollision{
+					ModelName: mInfo.Name,
+					Existing:  existingProp,
+					Found:     prop,
+				})
+			} else {
+				g.propertyConflicts = append(g.propertyConflicts, propertyTypeConflict{
+					ModelName: mInfo.Name,
+					Existing:  existingProp,
+					Found:     prop,
+				})
+			}
+			existingProp.examplesCount++
+			mInfo.Properties[prop.Name] = existingProp
+			return nil
+		}
+		mInfo.Properties[prop.Name] = existingProp.mergedWith(prop)
 	} else {
 		mInfo.Properties[prop.Name] = prop
 	}
+	if stored := mInfo.Properties[prop.Name]; stored.Enum != nil {
+		g.registerPropertyEnum(mInfo, stored)
+	}
 
-	valKind := reflect.TypeOf(propVal).Kind()
-	if valKind == reflect.Map || valKind == reflect.Array || valKind == reflect.Slice {
-		//TODO: if !prop.IsRawMap {
-		mInfo.ModelDependencies[g.getModelOrCreate(prop.Type)] = struct{}{}
-		//TODO: }
-		return g.mergeModelProperties(prop.Type, propVal)
+	if !prop.isModel {
+		return nil
+	}
+	propModelInfo := g.getModelOrCreate(prop.Type)
+	propModelInfo.isReferenced = true
+	mInfo.ModelDependencies[propModelInfo] = struct{}{}
+	g.addModelOrigin(mInfo, prop)
+	if err := g.setModelParent(prop.Type, attributes.extends); err != nil {
+		return err
+	}
+	if err := g.setModelDiscriminator(prop.Type, attributes.discriminator); err != nil {
+		return err
+	}
+	return g.mergeContainedModels(prop.Type, propVal, prop.Containers, prop.location.insideOf(mInfo.Name, prop.Name))
+}
+
+// mergeContainedModels merges the models found inside the containers of a property value
+func (g *Generator) mergeContainedModels(modelName string, val interface{}, containers []ContainerKind, location specLocation) error {
+	if val == nil {
+		return nil
+	}
+	if len(containers) == 0 {
+		return g.mergeModelProperties(modelName, val, location)
+	}
+
+	switch containers[0] {
+	case ArrayContainer:
+		array, isArray := val.([]interface{})
+		if !isArray {
+			return nil
+		}
+		for i, element := range array {
+			if err := g.mergeContainedModels(modelName, element, containers[1:], location.withIndex(i)); err != nil {
+				return err
+			}
+		}
+	case MapContainer:
+		dictionary, isMap := val.(map[string]interface{})
+		if !isMap {
+			return nil
+		}
+		for _, key := range sortedKeys(dictionary) {
+			if err := g.mergeContainedModels(modelName, dictionary[key], containers[1:], location.withProperty(key)); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
 
-func (g *Generator) setEndpointInfo(resourceModelAttrs, requestModelAttrs, responseModelAttrs modelAttributes, endpoint parser.Endpoint) (createdEndpointInfo endpointInfo) {
+func (g *Generator) setEndpointInfo(endpointAttrs endpointAttributes, resourceModelAttrs, requestModelAttrs, responseModelAttrs modelAttributes, envelope *envelope, endpoint parser.Endpoint) (createdEndpointInfo endpointInfo, err error) {
+	segmentParams, err := extractSegmentParamsRenamingDups(endpoint.Resources)
+	if err != nil {
+		return endpointInfo{}, err
+	}
+	requestFormat, err := requestFormatFromContent(requestModelAttrs.content)
+	if err != nil {
+		return endpointInfo{}, err
+	}
+	responseKind, err := responseKindFromContent(responseModelAttrs.content, endpoint.ResponseBody, responseModelAttrs.forceAsMap, responseModelAttrs.raw)
+	if err != nil {
+		return endpointInfo{}, err
+	}
+	pagination, err := newPagination(endpointAttrs, endpoint.Method, responseKind)
+	if err != nil {
+		return endpointInfo{}, err
+	}
+	urlQueryParams := endpoint.URL.Query()
+	if pagination != nil {
+		pagination.addQueryParams(urlQueryParams)
+		// The paginator looks for the items in the whole response
+		if envelope != nil && pagination.ItemsKeyPath == "" {
+			pagination.ItemsKeyPath = envelope.DataKey
+		}
+	}
+
 	// Get/Create the needed models
 	resourceModelInfo := g.getModelOrCreate(resourceModelAttrs.modelType)
 	requestModelInfo := g.getModelOrCreate(requestModelAttrs.modelType)
@@ -340,23 +685,27 @@ func (g *Generator) setEndpointInfo(resourceModelAttrs, requestModelAttrs, respo
 
 	// Build the endpoint
 	createdEndpointInfo = endpointInfo{
-		ResourceModel:  resourceModelInfo,
-		RequestModel:   requestModelInfo,
-		ResponseModel:  responseModelInfo,
-		Authenticates:  endpoint.Authenticates,
-		Method:         endpoint.Method,
-		URLPath:        g.getURLPathForModels(endpoint.URL),
-		URLQueryParams: endpoint.URL.Query(),
-		SegmentParams:  extractSegmentParamsRenamingDups(endpoint.Resources),
-		// TODO: Future: add RequestKind
-		ResponseKind: getResponseKind(endpoint.ResponseBody, responseModelAttrs.forceAsMap, responseModelAttrs.raw),
+		ResourceModel:      resourceModelInfo,
+		RequestModel:       requestModelInfo,
+		ResponseModel:      responseModelInfo,
+		Authenticates:      endpoint.Authenticates,
+		Method:             endpoint.Method,
+		URLPath:            g.getURLPathForModels(endpoint, segmentParams),
+		URLQueryParams:     urlQueryParams,
+		SegmentParams:      segmentParams,
+		RequestFormat:      requestFormat,
+		ResponseKind:       responseKind,
+		Pagination:         pagination,
+		Envelope:           envelope,
+		MethodResourceName: endpointAttrs.resourceName,
+		crudName:           endpointAttrs.verb,
 	}
 
 	// Add the dependencies
 	if createdEndpointInfo.NeedsModelParam() {
 		resourceModelInfo.EndpointsDependencies[requestModelInfo] = struct{}{}
 	}
-	if createdEndpointInfo.HasResponse() /*TODO: && !epi.IsRawMapResponse()*/ {
+	if createdEndpointInfo.HasResponse() && createdEndpointInfo.responseFormat() == contentJSON {
 		resourceModelInfo.EndpointsDependencies[responseModelInfo] = struct{}{}
 	}
 
@@ -365,11 +714,11 @@ func (g *Generator) setEndpointInfo(resourceModelAttrs, requestModelAttrs, respo
 }
 
 func (g *Generator) getModelOrCreate(modelName string) *modelInfo {
-	singularName := inflection.Singular(modelName)
-	mInfo, modelExists := g.modelsInfo[singularName]
+	name := g.modelName(modelName)
+	mInfo, modelExists := g.modelsInfo[name]
 	if !modelExists {
-		mInfo = newModelInfo(singularName)
-		g.modelsInfo[singularName] = mInfo
+		mInfo = newModelInfo(name)
+		g.modelsInfo[name] = mInfo
 	}
 	return mInfo
 }
@@ -399,19 +748,17 @@ func getResponseKind(body interface{}, forceAsMap, raw bool) ResponseKind {
 	}
 }
 
-func extractSegmentParamsRenamingDups(resources []parser.Resource) []string {
-	segmentParams := []string{}
-	for _, r := range resources {
-		//We assume that segment params have a unique name among the others in the same endpoint
-		segmentParams = append(segmentParams, r.Parameters...)
-	}
-	return segmentParams
-}
-
 type modelAttributes struct {
 	modelType  string
 	forceAsMap bool
 	raw        bool
+	extends    string
+	content    string
+
+	envelope     string
+	envelopeMeta string
+
+	discriminator string
 }
 
 func modelAttributesFromSpec(modelSpec string) (res modelAttributes) {
@@ -429,11 +776,66 @@ func modelAttributesFromSpec(modelSpec string) (res modelAttributes) {
 			res.forceAsMap = true
 		case attrKeyRaw:
 			res.raw = true
+		case attrKeyExtends:
+			res.extends = strings.TrimSpace(val)
+		case attrKeyContent:
+			res.content = strings.TrimSpace(val)
+		case attrKeyEnvelope:
+			res.envelope = strings.TrimSpace(val)
+		case attrKeyMeta:
+			res.envelopeMeta = strings.TrimSpace(val)
+		case attrKeyDiscriminator:
+			res.discriminator = strings.TrimSpace(val)
+		}
+	}
+	return
+}
+
+// endpointAttributes allow tuning the generated service method of an endpoint
+type endpointAttributes struct {
+	verb         string
+	resourceName string
+	service      string
+
+	pagination string
+	pageSize   string
+	items      string
+	next       string
+}
+
+func endpointAttributesFromSpec(endpointSpec string) (res endpointAttributes) {
+	attributes := strings.Split(endpointSpec, attrSeparator)
+	for _, attr := range attributes {
+		keyVal := strings.Split(attr, attrKeyValueSeparator)
+		val := ""
+		if len(keyVal) > 1 {
+			val = keyVal[1]
+		}
+		switch strings.TrimSpace(keyVal[0]) {
+		case attrKeyVerb:
+			res.verb = strings.TrimSpace(val)
+		case attrKeyResource:
+			res.resourceName = strings.TrimSpace(val)
+		case attrKeyService:
+			res.service = strings.TrimSpace(val)
+		case attrKeyPagination:
+			res.pagination = strings.TrimSpace(val)
+		case attrKeyPageSize:
+			res.pageSize = strings.TrimSpace(val)
+		case attrKeyItems:
+			res.items = strings.TrimSpace(val)
+		case attrKeyNext:
+			res.next = strings.TrimSpace(val)
 		}
 	}
 	return
 }
 
+// TemplateDir returns the directory with the templates of the language
+func TemplateDir(language Language) string {
+	return path.Join(templateDir, strings.ToLower(language.String()))
+}
+
 // New creates a new Generator for the API and configured for the language passed.
 func New(language Language, api *parser.API, config Config) (Generator, error) {
 	var gen languageSpecificGenerator
@@ -442,7 +844,7 @@ func New(language Language, api *parser.API, config Config) (Generator, error) {
 	switch language {
 	case ObjC:
 		gen = &ObjCGen{}
-		tplDir = path.Join(templateDir, strings.ToLower(language.String()))
+		tplDir = TemplateDir(language)
 		//	case Android:
 		//	case Swift:
 	default:
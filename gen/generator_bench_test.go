@@ -0,0 +1,69 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/alvaroloes/sdkgen/parser"
+)
+
+func BenchmarkGenerateSerially(b *testing.B) {
+	benchmarkGenerate(b, 1)
+}
+
+func BenchmarkGenerateConcurrently(b *testing.B) {
+	benchmarkGenerate(b, 0)
+}
+
+func benchmarkGenerate(b *testing.B, workers int) {
+	api, err := parser.NewAPI(largeAPISpec(300))
+	if err != nil {
+		b.Fatal(err)
+	}
+	outputDir, err := ioutil.TempDir("", "sdkgen")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer os.RemoveAll(outputDir)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		generator, err := New(ObjC, api, Config{
+			APIName:         "Bench",
+			APIPrefix:       "BN",
+			ModelsRelPath:   "Models",
+			ServicesRelPath: "Services",
+			OutputDir:       outputDir,
+			Workers:         workers,
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+		generator.tplDir = path.Join("..", generator.tplDir)
+		if err := generator.Generate(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// largeAPISpec returns a spec with an endpoint per resource, each one with a nested model
+func largeAPISpec(resources int) []byte {
+	var spec bytes.Buffer
+	for i := 0; i < resources; i++ {
+		fmt.Fprintf(&spec, `
+GET https://www.alvarloes.com/resources%[1]d/:id
+	<- {
+		"id": "1",
+		"title": "Title",
+		"count": 10,
+		"createdAt": "2016-01-02T15:04:05Z",
+		"details%[1]d": {"description": "Description", "tags": ["tag"]}
+	}
+`, i)
+	}
+	return spec.Bytes()
+}
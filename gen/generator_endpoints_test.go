@@ -0,0 +1,109 @@
+package gen
+
+import (
+	"testing"
+
+	"github.com/alvaroloes/sdkgen/parser"
+	"github.com/alvaroloes/sdkgen/tests"
+)
+
+type endpointTuningTestCase struct {
+	name            string
+	endpoint        parser.Endpoint
+	expectedService string
+	expectedCRUD    string
+	expectedName    string
+	// expectedResponseModel is the model of the response body, if any
+	expectedResponseModel string
+}
+
+var endpointTuningTestCases = []endpointTuningTestCase{
+	{
+		name: "No tuning",
+		endpoint: parser.Endpoint{
+			Method:    parser.POST,
+			URL:       tests.MustParseURL("https://www.alvarloes.com/posts/:id/publish"),
+			Resources: []parser.Resource{{Name: "posts", Parameters: []string{"id"}}, {Name: "publish"}},
+		},
+		expectedService: "publish",
+		expectedCRUD:    "create",
+	}, {
+		name: "Verb, resource name and service",
+		endpoint: parser.Endpoint{
+			Method:       parser.POST,
+			URL:          tests.MustParseURL("https://www.alvarloes.com/posts/:id/publish"),
+			Spec:         "verb = publish; resource = now; service = posts",
+			Resources:    []parser.Resource{{Name: "posts", Parameters: []string{"id"}}, {Name: "publish"}},
+			ResponseBody: map[string]interface{}{"id": "1"},
+		},
+		expectedService:       "post",
+		expectedCRUD:          "publish",
+		expectedName:          "now",
+		expectedResponseModel: "publish",
+	}, {
+		name: "Service with the response type declared",
+		endpoint: parser.Endpoint{
+			Method:       parser.POST,
+			URL:          tests.MustParseURL("https://www.alvarloes.com/posts/:id/publish"),
+			Spec:         "service = posts",
+			Resources:    []parser.Resource{{Name: "posts", Parameters: []string{"id"}}, {Name: "publish"}},
+			ResponseSpec: "type = publication",
+			ResponseBody: map[string]interface{}{"id": "1"},
+		},
+		expectedService:       "post",
+		expectedCRUD:          "create",
+		expectedResponseModel: "publication",
+	},
+}
+
+func TestEndpointTuning(t *testing.T) {
+	for _, testCase := range endpointTuningTestCases {
+		gen, ok := extractTestModelsInfo(t, testCase.name, &parser.API{Endpoints: []parser.Endpoint{testCase.endpoint}}, Config{}, nil)
+		if !ok {
+			continue
+		}
+
+		mInfo, found := gen.modelsInfo[testCase.expectedService]
+		if !found || len(mInfo.EndpointsInfo) != 1 {
+			t.Errorf("Test %q: Expected the endpoint in the service of model %q", testCase.name, testCase.expectedService)
+			continue
+		}
+		epi := mInfo.EndpointsInfo[0]
+		if crudName, _ := epi.CRUDMethodName(); crudName != testCase.expectedCRUD {
+			t.Errorf("Test %q: Expected CRUD method name %q, got: %q", testCase.name, testCase.expectedCRUD, crudName)
+		}
+		if epi.MethodResourceName != testCase.expectedName {
+			t.Errorf("Test %q: Expected method resource name %q, got: %q", testCase.name, testCase.expectedName, epi.MethodResourceName)
+		}
+		if testCase.expectedResponseModel != "" && epi.ResponseModel.Name != testCase.expectedResponseModel {
+			t.Errorf("Test %q: Expected response model %q, got: %q", testCase.name, testCase.expectedResponseModel, epi.ResponseModel.Name)
+		}
+	}
+}
+
+func TestFormatSuffixes(t *testing.T) {
+	endpoint := parser.Endpoint{
+		Method:       parser.GET,
+		URL:          tests.MustParseURL("https://www.alvarloes.com/posts/:id"),
+		FormatSuffix: ".json",
+		Resources:    []parser.Resource{{Name: "posts", Parameters: []string{"id"}}},
+		ResponseBody: map[string]interface{}{"id": "1"},
+	}
+	for _, keep := range []bool{false, true} {
+		gen := Generator{
+			api:    &parser.API{Endpoints: []parser.Endpoint{endpoint}},
+			config: Config{KeepFormatSuffixes: keep},
+		}
+		if err := gen.extractModelsInfo(); err != nil {
+			t.Errorf("Unexpected error keeping suffixes %v: %v", keep, err)
+			continue
+		}
+		expectedURLPath := "/posts/:id"
+		if keep {
+			expectedURLPath += ".json"
+		}
+		if urlPath := gen.modelsInfo["post"].EndpointsInfo[0].URLPath; urlPath != expectedURLPath {
+			t.Errorf("Expected URL path %q keeping suffixes %v, got: %q", expectedURLPath, keep, urlPath)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/alvaroloes/sdkgen/parser"
 	"github.com/alvaroloes/sdkgen/tests"
+	"github.com/juju/errors"
 	"github.com/kr/pretty"
 )
 
@@ -67,10 +68,10 @@ var modelsInfoTestCases = []modelsInfoTestCase{
 				EndpointsInfo: []endpointInfo{
 					{
 						Method:  parser.GET,
-						URLPath: "/posts/:id/comments/:id",
-						SegmentParams: []string{
-							"id",
-							"id",
+						URLPath: "/posts/:postId/comments/:commentId",
+						SegmentParams: []segmentParam{
+							{Name: "postId", Type: "string", TypeLabel: "string"},
+							{Name: "commentId", Type: "string", TypeLabel: "string"},
 						},
 						ResponseKind: 0,
 					},
@@ -96,3 +97,24 @@ func TestModelsInfo(t *testing.T) {
 		}
 	}
 }
+
+// extractTestModelsInfo extracts the models info of the API. It returns false if the extraction fails,
+// reporting it unless it fails with the expected error
+func extractTestModelsInfo(t *testing.T, testName string, api *parser.API, config Config, expectedError error) (Generator, bool) {
+	gen := Generator{
+		api:    api,
+		config: config,
+	}
+	err := gen.extractModelsInfo()
+	if expectedError != nil {
+		if errors.Cause(err) != expectedError {
+			t.Errorf("Test %q: Expected error %q, got: %v", testName, expectedError, err)
+		}
+		return gen, false
+	}
+	if err != nil {
+		t.Errorf("Test %q: Unexpected error: %v", testName, err)
+		return gen, false
+	}
+	return gen, true
+}
@@ -0,0 +1,392 @@
+package gen
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/alvaroloes/sdkgen/parser"
+	"github.com/juju/errors"
+)
+
+var outputSpec = []byte(`
+GET https://www.alvarloes.com/posts/:id
+	<- {
+		"id": "1",
+		"title": "Title",
+		"status: enum = draft|published": "draft",
+		"author": {"id": "1", "name": "Name"},
+		"comments": [{"id": "1", "text": "Text", "author": {"id": "2"}}],
+		"tags": [{"name": "tag"}],
+		"editor": {"id": "3"}
+	}
+
+POST https://www.alvarloes.com/posts
+	-> {
+		"title": "Title",
+		"reviewers": [{"id": "1"}]
+	}
+	<- {
+		"id": "1"
+	}
+`)
+
+// newOutputDir creates an empty directory to generate the SDK in. The caller must remove it
+func newOutputDir(t *testing.T) string {
+	outputDir, err := ioutil.TempDir("", "sdkgen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return outputDir
+}
+
+// generateSpec generates the SDK of the spec in the output directory. The overrides modify the config
+// of the tests, and can be nil
+func generateSpec(t *testing.T, spec []byte, outputDir string, overrides func(config *Config)) (Generator, error) {
+	api, err := parser.NewAPI(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := Config{
+		APIName:         "Test",
+		APIPrefix:       "TT",
+		ModelsRelPath:   "Models",
+		ServicesRelPath: "Services",
+		OutputDir:       outputDir,
+	}
+	if overrides != nil {
+		overrides(&config)
+	}
+	generator, err := New(ObjC, api, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The templates are relative to the repository root
+	generator.tplDir = path.Join("..", generator.tplDir)
+	return generator, generator.Generate()
+}
+
+// generateOutput generates the SDK of the spec in a new directory and returns the content of each file
+func generateOutput(t *testing.T, spec []byte, overrides func(config *Config)) map[string][]byte {
+	outputDir := newOutputDir(t)
+	defer os.RemoveAll(outputDir)
+	if _, err := generateSpec(t, spec, outputDir, overrides); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string][]byte{}
+	err := filepath.Walk(outputDir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		content, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		relPath, _ := filepath.Rel(outputDir, filePath)
+		files[relPath] = content
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func TestDeterministicOutput(t *testing.T) {
+	omitTimestamp := func(config *Config) {
+		config.OmitTimestamp = true
+	}
+	expectedFiles := generateOutput(t, outputSpec, omitTimestamp)
+	for i := 0; i < 5; i++ {
+		files := generateOutput(t, outputSpec, omitTimestamp)
+		if len(files) != len(expectedFiles) {
+			t.Fatalf("Expected %d files, got: %d", len(expectedFiles), len(files))
+		}
+		for fileName, content := range files {
+			if !bytes.Equal(content, expectedFiles[fileName]) {
+				t.Errorf("File %q changed between generations:\n%s\n\nvs\n\n%s", fileName, expectedFiles[fileName], content)
+			}
+			if bytes.Contains(content, []byte("Created on")) {
+				t.Errorf("File %q has the creation time", fileName)
+			}
+		}
+	}
+}
+
+func TestFileGenerationErrors(t *testing.T) {
+	tpl := template.Must(template.New("file").Parse("{{.Config.APIName}}"))
+	jobs := []fileJob{
+		{filePath: path.Join(os.TempDir(), "sdkgen-missing-dir", "A.h"), tpl: tpl, description: `file "A.h"`},
+		{filePath: path.Join(os.TempDir(), "sdkgen-missing-dir", "B.h"), tpl: tpl, description: `file "B.h"`},
+	}
+	for _, workers := range []int{1, 4} {
+		g := Generator{config: Config{Workers: workers}}
+		err := g.generateFiles(jobs)
+		if errors.Cause(err) != ErrFileGeneration {
+			t.Errorf("Expected error %q with %d workers, got: %v", ErrFileGeneration, workers, err)
+			continue
+		}
+		// The first error cancels the jobs not started yet, but all the errors found are reported
+		if !strings.Contains(err.Error(), `file "A.h"`) {
+			t.Errorf("Expected the error to name the failing file with %d workers, got: %v", workers, err)
+		}
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	outputDir := newOutputDir(t)
+	defer os.RemoveAll(outputDir)
+	generate := func(dryRun bool) (Generator, error) {
+		return generateSpec(t, outputSpec, outputDir, func(config *Config) {
+			config.DryRun = dryRun
+		})
+	}
+
+	// All the files are added to an empty directory, which is not modified
+	generator, err := generate(true)
+	if errors.Cause(err) != ErrOutputChanged {
+		t.Fatalf("Expected error %q, got: %v", ErrOutputChanged, err)
+	}
+	for _, change := range generator.Changes() {
+		if change.Kind != AddedFile {
+			t.Errorf("Expected file %q to be added, got: %s", change.Path, change.Kind)
+		}
+	}
+	if _, err := os.Stat(path.Join(outputDir, "Test")); !os.IsNotExist(err) {
+		t.Errorf("Expected the output directory to be empty after a dry run")
+	}
+
+	// Only the creation time changes when generating again
+	if _, err := generate(false); err != nil {
+		t.Fatal(err)
+	}
+	generator, err = generate(true)
+	if err != nil || len(generator.Changes()) > 0 {
+		t.Fatalf("Expected no changes, got: %v %v", err, generator.Changes())
+	}
+
+	// Files changed and not generated anymore are reported
+	modelPath := path.Join(outputDir, "Test", "Models", "TTPost.h")
+	if err := ioutil.WriteFile(modelPath, []byte("// Changed\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path.Join(outputDir, "Test", "Old.h"), []byte("// Old\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	addToManifest(t, path.Join(outputDir, "Test"), "Old.h")
+	// Files not created by the generator are never reported
+	if err := ioutil.WriteFile(path.Join(outputDir, "Test", "Mine.h"), []byte("// Mine\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	generator, err = generate(true)
+	if errors.Cause(err) != ErrOutputChanged {
+		t.Fatalf("Expected error %q, got: %v", ErrOutputChanged, err)
+	}
+	changes := generator.Changes()
+	if len(changes) != 2 || changes[0].Path != "Test/Models/TTPost.h" || changes[0].Kind != ModifiedFile ||
+		changes[1].Path != "Test/Old.h" || changes[1].Kind != RemovedFile {
+		t.Fatalf("Expected TTPost.h to be modified and Old.h removed, got: %v", changes)
+	}
+	if !strings.HasPrefix(changes[0].Diff, "--- a/Test/Models/TTPost.h\n+++ b/Test/Models/TTPost.h\n@@ -1,1 ") ||
+		!strings.Contains(changes[0].Diff, "\n-// Changed\n") {
+		t.Errorf("Expected the diff of TTPost.h, got:\n%s", changes[0].Diff)
+	}
+}
+
+// addToManifest lists the file in the manifest of the API directory as if it was generated by a previous run
+func addToManifest(t *testing.T, apiDir, file string) {
+	files, err := readManifest(apiDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writeManifest(apiDir, append(files, file)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStaleFiles(t *testing.T) {
+	outputDir := newOutputDir(t)
+	defer os.RemoveAll(outputDir)
+	generate := func(keepStaleFiles bool) {
+		_, err := generateSpec(t, outputSpec, outputDir, func(config *Config) {
+			config.KeepStaleFiles = keepStaleFiles
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	exists := func(file string) bool {
+		_, err := os.Stat(path.Join(outputDir, "Test", file))
+		return err == nil
+	}
+
+	generate(false)
+	apiDir := path.Join(outputDir, "Test")
+	files, err := readManifest(apiDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 || !exists("Models/TTPost.h") {
+		t.Fatalf("Expected the generated files in the manifest, got: %v", files)
+	}
+
+	// A model removed from the spec and a file created by the user
+	for _, file := range []string{"Models/TTOld.h", "Models/TTMine.h"} {
+		if err := ioutil.WriteFile(path.Join(apiDir, file), []byte("// File\n"), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	addToManifest(t, apiDir, "Models/TTOld.h")
+
+	// Files outside of the API directory listed in an edited manifest
+	outsidePath := path.Join(outputDir, "Outside.h")
+	absolutePath, err := filepath.Abs(path.Join(outputDir, "Absolute.h"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range []string{outsidePath, absolutePath} {
+		if err := ioutil.WriteFile(file, []byte("// File\n"), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	addToManifest(t, apiDir, "../Outside.h")
+	addToManifest(t, apiDir, "Models/../../Outside.h")
+	addToManifest(t, apiDir, filepath.ToSlash(absolutePath))
+
+	generate(true)
+	if !exists("Models/TTOld.h") || !exists("Models/TTMine.h") {
+		t.Errorf("Expected the stale files to be kept")
+	}
+
+	generate(false)
+	if exists("Models/TTOld.h") {
+		t.Errorf("Expected the stale file to be removed")
+	}
+	if !exists("Models/TTMine.h") || !exists("Models/TTPost.h") {
+		t.Errorf("Expected the files not created by the generator and the generated ones to be kept")
+	}
+	for _, file := range []string{outsidePath, absolutePath} {
+		if _, err := os.Stat(file); err != nil {
+			t.Errorf("Expected the file %q outside of the API directory to be kept", file)
+		}
+	}
+	files, err = readManifest(apiDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		if file == "Models/TTOld.h" || file == "Models/TTMine.h" {
+			t.Errorf("Expected %q not to be in the manifest", file)
+		}
+	}
+}
+
+func TestModelExtensions(t *testing.T) {
+	outputDir := newOutputDir(t)
+	defer os.RemoveAll(outputDir)
+	generate := func(dryRun bool) (Generator, error) {
+		return generateSpec(t, outputSpec, outputDir, func(config *Config) {
+			config.ExtensionsRelPath = "Extensions"
+			config.DryRun = dryRun
+		})
+	}
+
+	if _, err := generate(false); err != nil {
+		t.Fatal(err)
+	}
+	extensionPath := path.Join(outputDir, "Test", "Extensions", "TTPost+Custom.h")
+	content, err := ioutil.ReadFile(extensionPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "@interface TTPost (Custom)") {
+		t.Errorf("Expected the TTPost category, got:\n%s", content)
+	}
+	apiHeader, err := ioutil.ReadFile(path.Join(outputDir, "Test", "Test.h"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(apiHeader), `#import "TTPost+Custom.h"`) {
+		t.Errorf("Expected the API header to import the extensions, got:\n%s", apiHeader)
+	}
+
+	// The code added to the extensions is kept and they are not reported by dry runs
+	customContent := []byte("// Custom code\n")
+	if err := ioutil.WriteFile(extensionPath, customContent, 0666); err != nil {
+		t.Fatal(err)
+	}
+	generator, err := generate(true)
+	if err != nil || len(generator.Changes()) > 0 {
+		t.Fatalf("Expected no changes, got: %v %v", err, generator.Changes())
+	}
+	if _, err := generate(false); err != nil {
+		t.Fatal(err)
+	}
+	if content, _ := ioutil.ReadFile(extensionPath); !bytes.Equal(content, customContent) {
+		t.Errorf("Expected the extension to be kept, got:\n%s", content)
+	}
+
+	// They are never removed as stale files
+	files, err := readManifest(path.Join(outputDir, "Test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		if strings.HasPrefix(file, "Extensions/") {
+			t.Errorf("Expected %q not to be in the manifest", file)
+		}
+	}
+}
+
+func TestBaseModelWithoutProperties(t *testing.T) {
+	spec := []byte(`
+GET https://www.alvarloes.com/cats/:id
+	<- extends = animal {"meow": "loud"}
+
+GET https://www.alvarloes.com/dogs/:id
+	<- extends = animal {"bark": "loud"}
+`)
+	files := generateOutput(t, spec, nil)
+	if _, found := files[path.Join("Test", "Models", "TTAnimal.h")]; !found {
+		t.Fatalf("Expected the base model to be generated even if it has no properties")
+	}
+	if cat := string(files[path.Join("Test", "Models", "TTCat.h")]); !strings.Contains(cat, "@interface TTCat : TTAnimal") {
+		t.Errorf("Expected TTCat to extend TTAnimal, got:\n%s", cat)
+	}
+	if apiHeader := string(files[path.Join("Test", "Test.h")]); !strings.Contains(apiHeader, `#import "TTAnimal.h"`) {
+		t.Errorf("Expected the API header to import the base model, got:\n%s", apiHeader)
+	}
+}
+
+func TestForcedModelTypeOfScalarValues(t *testing.T) {
+	spec := []byte(`
+GET https://www.alvaroloes.com/api/v1/posts
+	<- type = SuperPost [
+		{
+			"id": "1234",
+			"author:type = person; name = authorazo; map": {
+				"isAdmin": false,
+				"name": "John",
+				"age": 20
+			}
+		}
+	]
+`)
+	files := generateOutput(t, spec, nil)
+	post := string(files[path.Join("Test", "Models", "TTSuperPost.h")])
+	if !strings.Contains(post, "NSDictionary<NSString *, TTPerson *> *authorazo") {
+		t.Fatalf("Expected the property to be a map of the declared model, got:\n%s", post)
+	}
+	if !strings.Contains(post, "TTPerson;") && !strings.Contains(post, `#import "TTPerson.h"`) {
+		t.Errorf("Expected TTSuperPost to reference the declared model, got:\n%s", post)
+	}
+	if _, found := files[path.Join("Test", "Models", "TTPerson.h")]; !found {
+		t.Errorf("Expected the declared model to be generated")
+	}
+}
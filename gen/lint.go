@@ -0,0 +1,274 @@
+package gen
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/alvaroloes/sdkgen/parser"
+	"github.com/juju/errors"
+)
+
+// Rules checked by the linter
+const (
+	LintNoBodyExamples    = "no-body-examples"
+	LintUntypedProperty   = "untyped-property"
+	LintPropertyName      = "property-name"
+	LintNamingStyle       = "naming-style"
+	LintDuplicateEndpoint = "duplicate-endpoint"
+	LintUnusedType        = "unused-type"
+	LintAuthRefreshToken  = "auth-refresh-token"
+	LintTypeConflict      = "type-conflict"
+	LintModelCollision    = "model-collision"
+)
+
+// LintIssue is a problem found in the API spec that makes the generated code worse or wrong
+type LintIssue struct {
+	Rule     string `json:"rule"`
+	Location string `json:"location"`
+	Message  string `json:"message"`
+	// Position is where the issue is in the spec. It is nil if it's not in a specific place
+	Position *SpecPosition `json:"-"`
+}
+
+func (i LintIssue) String() string {
+	return fmt.Sprintf("%s: %s [%s]", i.Location, i.Message, i.Rule)
+}
+
+// Naming styles of the property names. Names with only lowercase letters fit any style
+const (
+	camelCaseStyle  = "camelCase"
+	snakeCaseStyle  = "snake_case"
+	kebabCaseStyle  = "kebab-case"
+	pascalCaseStyle = "PascalCase"
+)
+
+var identifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Lint extracts the models of the API spec and returns the problems found in them, grouped by rule:
+// the endpoint ones in the order of the spec, then the model ones sorted by model and property name.
+// The property conflicts and model collisions are returned as issues, even with strict property types.
+// Only the errors that prevent extracting the models are returned as errors
+func (g *Generator) Lint() ([]LintIssue, error) {
+	if err := g.extractModels(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var issues []LintIssue
+	issues = append(issues, g.lintEndpoints()...)
+	issues = append(issues, g.lintModels()...)
+	issues = append(issues, g.lintNamingStyles()...)
+	if g.authInfo != nil && g.authInfo.RefreshTokenProp == "" {
+		issues = append(issues, LintIssue{
+			Rule:     LintAuthRefreshToken,
+			Location: endpointDescription(g.authInfo.Endpoint.Method, g.authInfo.Endpoint.URLPath),
+			Message:  fmt.Sprintf("the authentication response has no %q property, so the session can't be renewed when the access token expires", refreshTokenPropName),
+			Position: g.authEndpointPosition(),
+		})
+	}
+	for _, conflict := range g.propertyConflicts {
+		issues = append(issues, LintIssue{
+			Rule:     LintTypeConflict,
+			Location: conflict.Found.location.String(),
+			Message:  conflict.String(),
+			Position: g.lintPosition(conflict.Found.location),
+		})
+	}
+	for _, collision := range g.modelNameCollisions {
+		issues = append(issues, LintIssue{
+			Rule:     LintModelCollision,
+			Location: collision.Found.location.String(),
+			Message:  collision.String(),
+			Position: g.lintPosition(collision.Found.location),
+		})
+	}
+	return issues, nil
+}
+
+// lintPosition returns the position of the location in the spec
+func (g *Generator) lintPosition(location specLocation) *SpecPosition {
+	position := g.specPosition(location)
+	return &position
+}
+
+// authEndpointPosition returns the position of the authentication endpoint in the spec
+func (g *Generator) authEndpointPosition() *SpecPosition {
+	for i, endpoint := range g.api.Endpoints {
+		if endpoint.Authenticates {
+			return &SpecPosition{Endpoint: i}
+		}
+	}
+	return nil
+}
+
+// hasNonJSONContent returns whether the body spec declares a content whose examples are optional
+func hasNonJSONContent(bodySpec string) bool {
+	content := modelAttributesFromSpec(bodySpec).content
+	return content != "" && content != contentJSON
+}
+
+func endpointDescription(method parser.HTTPMethod, urlPath string) string {
+	return fmt.Sprintf("%s %s", method, urlPath)
+}
+
+// lintEndpoints reports the endpoints without the body examples the models are extracted from
+// and the ones declared more than once
+func (g *Generator) lintEndpoints() []LintIssue {
+	var issues []LintIssue
+	declared := map[string]struct{}{}
+	for i, endpoint := range g.api.Endpoints {
+		location := endpointDescription(endpoint.Method, endpoint.URL.Path)
+		position := &SpecPosition{Endpoint: i}
+		switch {
+		case endpoint.Method == parser.GET && endpoint.ResponseBody == nil && !hasNonJSONContent(endpoint.ResponseSpec):
+			issues = append(issues, LintIssue{
+				Rule:     LintNoBodyExamples,
+				Location: location,
+				Message:  "the endpoint has no response body example, so the type of its response is unknown",
+				Position: position,
+			})
+		case (endpoint.Method == parser.POST || endpoint.Method == parser.PUT) && endpoint.RequestBody == nil && endpoint.ResponseBody == nil:
+			issues = append(issues, LintIssue{
+				Rule:     LintNoBodyExamples,
+				Location: location,
+				Message:  "the endpoint has no request nor response body examples",
+				Position: position,
+			})
+		}
+
+		// The segment params can have different names in each declaration
+		segments := strings.Split(endpoint.URL.Path, "/")
+		for i, segment := range segments {
+			if strings.HasPrefix(segment, ":") {
+				segments[i] = ":"
+			}
+		}
+		key := endpoint.Method.String() + " " + strings.Join(segments, "/")
+		if _, found := declared[key]; found {
+			issues = append(issues, LintIssue{
+				Rule:     LintDuplicateEndpoint,
+				Location: location,
+				Message:  "the endpoint is declared more than once. Only one service method can be generated for it",
+				Position: position,
+			})
+		}
+		declared[key] = struct{}{}
+	}
+	return issues
+}
+
+// lintModels reports the properties whose type is unknown or whose name can't be used in the
+// generated code, and the models declared without properties
+func (g *Generator) lintModels() []LintIssue {
+	var issues []LintIssue
+	for _, mInfo := range g.sortedModelsInfo() {
+		if len(mInfo.Properties) == 0 && len(mInfo.EndpointsInfo) == 0 && mInfo.Parent == nil && len(mInfo.Variants) == 0 {
+			issues = append(issues, LintIssue{
+				Rule:     LintUnusedType,
+				Location: fmt.Sprintf("model %q", mInfo.Name),
+				Message:  "the model has no properties in any example nor endpoints, so it is generated empty. Remove its type or add an example",
+			})
+		}
+		for _, propName := range sortedPropertyNames(mInfo.Properties) {
+			prop := mInfo.Properties[propName]
+			if prop.Type == "" && !prop.IsRaw {
+				issues = append(issues, LintIssue{
+					Rule:     LintUntypedProperty,
+					Location: prop.location.String(),
+					Message:  fmt.Sprintf("the type of property %q of model %q is unknown as it is always null or empty. Add an example or declare it with %q", prop.Name, mInfo.Name, attrKeyType+" "+attrKeyValueSeparator),
+					Position: g.lintPosition(prop.location),
+				})
+			}
+			switch _, reserved := invalidPropertyNames[prop.NameLabel]; {
+			case !identifierRegexp.MatchString(prop.NameLabel):
+				issues = append(issues, LintIssue{
+					Rule:     LintPropertyName,
+					Location: prop.location.String(),
+					Message:  fmt.Sprintf("property %q of model %q is not a valid identifier. Rename it with %q", prop.NameLabel, mInfo.Name, attrKeyName+" "+attrKeyValueSeparator),
+					Position: g.lintPosition(prop.location),
+				})
+			case reserved:
+				issues = append(issues, LintIssue{
+					Rule:     LintPropertyName,
+					Location: prop.location.String(),
+					Message:  fmt.Sprintf("property %q of model %q is reserved, so it is generated as %q", prop.NameLabel, mInfo.Name, prop.NameLabel+suffixForInvalidPropNames),
+					Position: g.lintPosition(prop.location),
+				})
+			}
+		}
+	}
+	return issues
+}
+
+// lintNamingStyles reports the property names not following the naming style of most of them
+func (g *Generator) lintNamingStyles() []LintIssue {
+	type namedProperty struct {
+		modelName string
+		prop      property
+		style     string
+	}
+	var props []namedProperty
+	countPerStyle := map[string]int{}
+	for _, mInfo := range g.sortedModelsInfo() {
+		for _, propName := range sortedPropertyNames(mInfo.Properties) {
+			prop := mInfo.Properties[propName]
+			if style := namingStyle(prop.Name); style != "" {
+				props = append(props, namedProperty{modelName: mInfo.Name, prop: prop, style: style})
+				countPerStyle[style]++
+			}
+		}
+	}
+
+	mainStyle := ""
+	for _, style := range []string{camelCaseStyle, snakeCaseStyle, kebabCaseStyle, pascalCaseStyle} {
+		if countPerStyle[style] > countPerStyle[mainStyle] {
+			mainStyle = style
+		}
+	}
+	var issues []LintIssue
+	for _, p := range props {
+		if p.style != mainStyle {
+			issues = append(issues, LintIssue{
+				Rule:     LintNamingStyle,
+				Location: p.prop.location.String(),
+				Message:  fmt.Sprintf("property %q of model %q is %s, but most properties are %s", p.prop.Name, p.modelName, p.style, mainStyle),
+				Position: g.lintPosition(p.prop.location),
+			})
+		}
+	}
+	return issues
+}
+
+// namingStyle returns the style of the name, or an empty string if it fits any of them
+func namingStyle(name string) string {
+	switch {
+	case strings.Contains(name, "_"):
+		return snakeCaseStyle
+	case strings.Contains(name, "-"):
+		return kebabCaseStyle
+	case name != "" && strings.ToUpper(name[:1]) == name[:1] && strings.ToLower(name[:1]) != name[:1]:
+		return pascalCaseStyle
+	case strings.ToLower(name) != name:
+		return camelCaseStyle
+	}
+	return ""
+}
+
+func (g *Generator) sortedModelsInfo() []*modelInfo {
+	models := make([]*modelInfo, 0, len(g.modelsInfo))
+	for _, mInfo := range g.modelsInfo {
+		models = append(models, mInfo)
+	}
+	sort.Sort(modelsByName(models))
+	return models
+}
+
+func sortedPropertyNames(props map[string]property) []string {
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
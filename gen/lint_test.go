@@ -0,0 +1,124 @@
+package gen
+
+import (
+	"testing"
+
+	"github.com/alvaroloes/sdkgen/parser"
+)
+
+var lintSpec = []byte(`
+AUTH_TOKEN POST https://www.alvarloes.com/login
+	-> {
+		"username": "user",
+		"password": "pass"
+	}
+	<- {
+		"accessToken": "token",
+		"tokenType": "bearer"
+	}
+
+GET https://www.alvarloes.com/posts/:id
+	<- {
+		"id": "1",
+		"title": "Title",
+		"description": "Description",
+		"createdAt": "2016-01-01T00:00:00Z",
+		"publishedAt": "2016-01-01T00:00:00Z",
+		"comment_count": 1,
+		"tags": [],
+		"first-reader: type = reader": null
+	}
+
+GET https://www.alvarloes.com/posts/:postId
+
+GET https://www.alvarloes.com/posts/:id/cover
+	<- content = binary
+
+PUT https://www.alvarloes.com/posts/:id
+`)
+
+func TestLint(t *testing.T) {
+	api, err := parser.NewAPI(lintSpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	generator, err := New(ObjC, api, Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	issues, err := generator.Lint()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedIssues := []LintIssue{
+		{Rule: LintNoBodyExamples, Location: "GET /posts/:postId"},
+		{Rule: LintDuplicateEndpoint, Location: "GET /posts/:postId"},
+		{Rule: LintNoBodyExamples, Location: "PUT /posts/:id"},
+		{Rule: LintPropertyName, Location: `GET /posts/:id (response body) at "description"`},
+		{Rule: LintPropertyName, Location: `GET /posts/:id (response body) at "first-reader"`},
+		{Rule: LintUntypedProperty, Location: `GET /posts/:id (response body) at "tags"`},
+		{Rule: LintUnusedType, Location: `model "reader"`},
+		{Rule: LintNamingStyle, Location: `GET /posts/:id (response body) at "comment_count"`},
+		{Rule: LintNamingStyle, Location: `GET /posts/:id (response body) at "first-reader"`},
+		{Rule: LintAuthRefreshToken, Location: "POST /login"},
+	}
+	if len(issues) != len(expectedIssues) {
+		t.Fatalf("Expected %d issues, got %d: %v", len(expectedIssues), len(issues), issues)
+	}
+	for i, issue := range issues {
+		if issue.Rule != expectedIssues[i].Rule || issue.Location != expectedIssues[i].Location {
+			t.Errorf("Expected issue %q at %q, got: %v", expectedIssues[i].Rule, expectedIssues[i].Location, issue)
+		}
+		if issue.Message == "" {
+			t.Errorf("Expected a message for issue %v", issue)
+		}
+	}
+}
+
+var conflictingLintSpec = []byte(`
+GET https://www.alvarloes.com/posts/:id
+	<- {
+		"id": "1",
+		"author": {"id": 1}
+	}
+
+GET https://www.alvarloes.com/books/:id
+	<- {
+		"id": 1,
+		"author": {"id": "tolkien"}
+	}
+
+PUT https://www.alvarloes.com/posts/:id
+	-> {
+		"id": 1
+	}
+`)
+
+func TestLintStrictPropertyTypes(t *testing.T) {
+	api, err := parser.NewAPI(conflictingLintSpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	generator, err := New(ObjC, api, Config{StrictPropertyTypes: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	issues, err := generator.Lint()
+	if err != nil {
+		t.Fatalf("Expected the conflicts as issues, got the error: %v", err)
+	}
+
+	expectedIssues := []LintIssue{
+		{Rule: LintTypeConflict, Location: `PUT /posts/:id (request body) at "id"`},
+		{Rule: LintModelCollision, Location: `GET /books/:id (response body) at "author.id"`},
+	}
+	if len(issues) != len(expectedIssues) {
+		t.Fatalf("Expected %d issues, got %d: %v", len(expectedIssues), len(issues), issues)
+	}
+	for i, issue := range issues {
+		if issue.Rule != expectedIssues[i].Rule || issue.Location != expectedIssues[i].Location {
+			t.Errorf("Expected issue %q at %q, got: %v", expectedIssues[i].Rule, expectedIssues[i].Location, issue)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package gen
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/juju/errors"
+)
+
+// manifestFileName is the file in the API directory listing the files generated by the last run,
+// so that the ones not generated anymore can be removed without touching the files created by others
+const manifestFileName = ".sdkgen-manifest"
+
+// readManifest returns the files listed in the manifest of the API directory, relative to it.
+// There are no files if there is no manifest
+func readManifest(apiDir string) ([]string, error) {
+	content, err := ioutil.ReadFile(filepath.Join(apiDir, manifestFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var files []string
+	for _, line := range strings.Split(string(content), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+func writeManifest(apiDir string, files []string) error {
+	sorted := append([]string(nil), files...)
+	sort.Strings(sorted)
+	content := strings.Join(sorted, "\n") + "\n"
+	return errors.Trace(ioutil.WriteFile(filepath.Join(apiDir, manifestFileName), []byte(content), filePermissions))
+}
+
+// isInsideDir returns whether the slash separated path is relative to a directory and doesn't go out of it
+func isInsideDir(file string) bool {
+	localPath := filepath.FromSlash(file)
+	if filepath.IsAbs(localPath) || filepath.VolumeName(localPath) != "" {
+		return false
+	}
+	cleanPath := filepath.Clean(localPath)
+	return cleanPath != "." && cleanPath != ".." && !strings.HasPrefix(cleanPath, ".."+string(filepath.Separator))
+}
+
+// staleFiles returns the files of the manifest that are not generated anymore
+func staleFiles(manifestFiles, generatedFiles []string) []string {
+	generated := map[string]struct{}{}
+	for _, file := range generatedFiles {
+		generated[file] = struct{}{}
+	}
+	var stale []string
+	for _, file := range manifestFiles {
+		if _, found := generated[file]; !found {
+			stale = append(stale, file)
+		}
+	}
+	return stale
+}
+
+// updateManifest removes the files generated by the previous run that are not generated anymore
+// (or only reports them if KeepStaleFiles is set) and lists the new ones in the manifest.
+// The files only created once belong to the user, so they are not listed
+func (g *Generator) updateManifest(apiDir string, jobs []fileJob) error {
+	generatedFiles := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		if job.createOnly {
+			continue
+		}
+		relPath, err := filepath.Rel(apiDir, job.filePath)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		generatedFiles = append(generatedFiles, filepath.ToSlash(relPath))
+	}
+
+	manifestFiles, err := readManifest(apiDir)
+	if err != nil {
+		return errors.Annotate(err, "when reading the manifest")
+	}
+	for _, file := range staleFiles(manifestFiles, generatedFiles) {
+		// The manifest could have been edited, and only the files of the API directory can be removed
+		if !isInsideDir(file) {
+			log.Warnf("ignoring the manifest entry %q, as it is outside of the API directory", file)
+			continue
+		}
+		if g.config.KeepStaleFiles {
+			log.Warnf("file %q is not generated anymore", file)
+			// It is kept in the manifest to be removed in a later run
+			generatedFiles = append(generatedFiles, file)
+			continue
+		}
+		if err := os.Remove(filepath.Join(apiDir, filepath.FromSlash(file))); err != nil && !os.IsNotExist(err) {
+			return errors.Annotatef(err, "when removing the stale file %q", file)
+		}
+		log.Infof("removed the stale file %q", file)
+	}
+
+	return errors.Annotate(writeManifest(apiDir, generatedFiles), "when writing the manifest")
+}
@@ -1,7 +1,10 @@
 package gen
 
 import (
+	"encoding/json"
+	"math"
 	"reflect"
+	"sort"
 
 	"net/url"
 
@@ -24,6 +27,30 @@ const (
 	ArrayResponse
 	RawArrayResponse
 	EmptyResponse
+	TextResponse
+	BoolResponse
+	BinaryResponse
+)
+
+//go:generate enumer -type=RequestFormat
+
+// RequestFormat is how the request body is encoded
+type RequestFormat int
+
+const (
+	JSONRequest RequestFormat = iota
+	FormRequest
+	MultipartRequest
+)
+
+//go:generate enumer -type=ContainerKind
+
+// ContainerKind is the kind of each of the containers that nest a property value
+type ContainerKind int
+
+const (
+	ArrayContainer ContainerKind = iota
+	MapContainer
 )
 
 // Property and model specification
@@ -36,8 +63,65 @@ const (
 	attrKeyType = "type"
 	attrKeyMap  = "map"
 	attrKeyRaw  = "raw"
+
+	attrKeyFormat  = "format"
+	attrKeyEnum    = "enum"
+	attrKeyExtends = "extends"
+
+	attrKeyDiscriminator = "discriminator"
+	attrKeyContent       = "content"
+	attrKeyEnvelope      = "envelope"
+	attrKeyMeta          = "meta"
+
+	attrKeyVerb     = "verb"
+	attrKeyResource = "resource"
+	attrKeyService  = "service"
+
+	attrKeyPagination = "pagination"
+	attrKeyPageSize   = "pageSize"
+	attrKeyItems      = "items"
+	attrKeyNext       = "next"
+)
+
+// Attributes that can be declared in the property, body and endpoint specifications, so that editors can complete them
+var (
+	PropertyAttributeKeys = []string{attrKeyName, attrKeyType, attrKeyMap, attrKeyRaw, attrKeyFormat, attrKeyEnum, attrKeyExtends, attrKeyDiscriminator}
+	BodyAttributeKeys     = []string{attrKeyType, attrKeyMap, attrKeyRaw, attrKeyExtends, attrKeyDiscriminator, attrKeyContent, attrKeyEnvelope, attrKeyMeta}
+	EndpointAttributeKeys = []string{attrKeyVerb, attrKeyResource, attrKeyService, attrKeyPagination, attrKeyPageSize, attrKeyItems, attrKeyNext}
 )
 
+// Basic property types. Numbers are typed with the narrowest type that can hold them
+const (
+	typeInt     = "int"
+	typeInt64   = "int64"
+	typeFloat64 = "float64"
+	// typeFile is a file to upload in a multipart request
+	typeFile = "file"
+)
+
+// basicTypes are the types that are not models
+var basicTypes = map[string]struct{}{
+	"string":    {},
+	"bool":      {},
+	typeInt:     {},
+	typeInt64:   {},
+	typeFloat64: {},
+	typeDate:    {},
+	typeFile:    {},
+}
+
+func isBasicType(typeName string) bool {
+	_, isBasic := basicTypes[typeName]
+	return isBasic
+}
+
+// numericTypeWidth allows to choose the type that can hold all the numbers of a property
+var numericTypeWidth = map[string]int{
+	typeInt:     0,
+	typeInt64:   1,
+	typeFloat64: 2,
+}
+
 var crudNamePerMethod = map[parser.HTTPMethod]string{
 	parser.GET:    "fetch",
 	parser.POST:   "create",
@@ -88,6 +172,25 @@ type modelInfo struct {
 	EndpointsInfo         []endpointInfo
 	ModelDependencies     map[*modelInfo]struct{}
 	EndpointsDependencies map[*modelInfo]struct{}
+	EnumDependencies      map[*enumInfo]struct{}
+	// Parent is the base model this one extends, if any. Its properties are not repeated in this model
+	Parent *modelInfo
+	// Discriminator is the name of the property that tells which variant (subclass) of this model an object is
+	Discriminator string
+	// Variants are the models extending this one per discriminator value
+	Variants map[string]*modelInfo
+
+	examplesCount int
+	// hasChildren tells whether other models extend this one
+	hasChildren bool
+	// isReferenced tells whether properties of other models are of this model
+	isReferenced bool
+}
+
+// HasModelFile tells whether the model is generated. Models extending, extended by or referenced by
+// other ones are generated even if they have no properties of their own, as the generated models refer to them
+func (mi *modelInfo) HasModelFile() bool {
+	return len(mi.Properties) > 0 || mi.Parent != nil || mi.hasChildren || mi.isReferenced
 }
 
 func (mi *modelInfo) DependsOnModel(modelName string) bool {
@@ -99,6 +202,42 @@ func (mi *modelInfo) DependsOnModel(modelName string) bool {
 	return false
 }
 
+// SortedModelDependencies returns the model dependencies sorted by name, so that the generated
+// code doesn't depend on the map iteration order
+func (mi *modelInfo) SortedModelDependencies() []*modelInfo {
+	return sortedModels(mi.ModelDependencies)
+}
+
+// SortedEndpointsDependencies returns the endpoints dependencies sorted by name
+func (mi *modelInfo) SortedEndpointsDependencies() []*modelInfo {
+	return sortedModels(mi.EndpointsDependencies)
+}
+
+func sortedModels(models map[*modelInfo]struct{}) []*modelInfo {
+	sorted := make([]*modelInfo, 0, len(models))
+	for mInfo := range models {
+		sorted = append(sorted, mInfo)
+	}
+	sort.Sort(modelsByName(sorted))
+	return sorted
+}
+
+type modelsByName []*modelInfo
+
+func (m modelsByName) Len() int           { return len(m) }
+func (m modelsByName) Swap(i, j int)      { m[i], m[j] = m[j], m[i] }
+func (m modelsByName) Less(i, j int) bool { return m[i].Name < m[j].Name }
+
+// HasPaginatedEndpoints returns whether any endpoint of the model is paginated
+func (mi *modelInfo) HasPaginatedEndpoints() bool {
+	for _, epi := range mi.EndpointsInfo {
+		if epi.Pagination != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func newModelInfo(name string) *modelInfo {
 	return &modelInfo{
 		Name:                  name,
@@ -106,6 +245,8 @@ func newModelInfo(name string) *modelInfo {
 		Properties:            make(map[string]property),
 		ModelDependencies:     make(map[*modelInfo]struct{}),
 		EndpointsDependencies: make(map[*modelInfo]struct{}),
+		EnumDependencies:      make(map[*enumInfo]struct{}),
+		Variants:              make(map[string]*modelInfo),
 	}
 }
 
@@ -116,13 +257,35 @@ type property struct {
 	TypeLabel string
 	IsArray   bool
 	IsMap     bool
+	// Containers are the arrays and maps nesting the values, from the outermost to the innermost.
+	// IsArray and IsMap refer to the outermost one
+	Containers []ContainerKind
+	// IsNullable is true when the property is null in some example
+	IsNullable bool
+	// IsOptional is true when the property is missing in some example of its model
+	IsOptional bool
+	// DateFormat is the format of the date values (only for date properties)
+	DateFormat string
+	// DateInString is true when the date values are encoded in strings, even if they are epochs
+	DateInString bool
+	// Enum is the enum type of the property (only for enum properties)
+	Enum *enumInfo
+	// IsRaw is true when the value is kept untyped: IsMap or IsArray tell whether it is
+	// a dictionary or an array. Otherwise it can be any value
+	IsRaw bool
+
+	attributes    propertyAttributes
+	location      specLocation
+	examplesCount int
+	isModel       bool
 }
 
-func newProperty(propertySpec string, val interface{}) property {
+func newProperty(attributes propertyAttributes, val interface{}, location specLocation) property {
 	var p property
-	attributes := newPropertyAttributes(propertySpec)
-
+	p.attributes = attributes
+	p.location = location
 	p.Name = attributes.name
+	p.examplesCount = 1
 	if attributes.nameLabel != "" {
 		p.NameLabel = attributes.nameLabel
 	} else {
@@ -133,25 +296,263 @@ func newProperty(propertySpec string, val interface{}) property {
 }
 
 func (p *property) extractType(attributes propertyAttributes, val interface{}) {
-	value := reflect.TypeOf(val)
-	switch value.Kind() {
-	case reflect.Map:
+	if attributes.raw {
+		p.extractRawType(val)
+		return
+	}
+	if val == nil {
+		// The type can only be known by the attributes or other examples
+		p.IsNullable = true
+		p.isModel = attributes.forcedType != "" && !isBasicType(attributes.forcedType)
+		if attributes.forceAsMap {
+			p.Containers = []ContainerKind{MapContainer}
+			p.IsMap = true
+		}
+		p.applyForcedType(attributes)
+		return
+	}
+
+	var leaf interface{}
+	p.Containers, leaf = containedValue(val, attributes.forceAsMap)
+	if len(p.Containers) > 0 {
+		p.IsArray = p.Containers[0] == ArrayContainer
+		p.IsMap = p.Containers[0] == MapContainer
+	}
+
+	if leaf == nil {
+		// All the containers are empty, so the type is unknown
+	} else if reflect.TypeOf(leaf).Kind() == reflect.Map {
 		// The value is an object, the type name is the property name
 		p.Type = inflection.Singular(p.Name)
+		p.isModel = true
+	} else {
+		allowEpoch := attributes.forcedType == typeDate || attributes.dateFormat != "" || looksLikeDateName(p.Name)
+		if dateFormat, inString := dateFormatFromValue(leaf, allowEpoch); dateFormat != "" {
+			p.Type = typeDate
+			p.DateFormat = dateFormat
+			p.DateInString = inString
+		} else if number, isNumber := leaf.(json.Number); isNumber {
+			p.Type = numberType(number)
+		} else {
+			p.Type = reflect.TypeOf(leaf).String()
+			p.DateInString = p.Type == "string"
+		}
+	}
+	// A model type declared for values that are not objects, like the ones of the example maps
+	if attributes.forcedType != "" && !isBasicType(attributes.forcedType) {
+		p.isModel = true
+	}
+	p.applyForcedType(attributes)
+}
+
+// extractRawType sets the property as raw. Its type stays unknown, as no model is created for it
+func (p *property) extractRawType(val interface{}) {
+	p.IsRaw = true
+	if val == nil {
+		p.IsNullable = true
+		return
+	}
+	switch reflect.TypeOf(val).Kind() {
+	case reflect.Map:
+		p.IsMap = true
 	case reflect.Array, reflect.Slice:
 		p.IsArray = true
+	}
+}
+
+// containedValue returns the kinds of the containers nesting the value and the first value found
+// in them that is not a container. The leaf is nil if the containers are empty or only contain nulls.
+// Objects are containers only if asMap is true, and only the outermost one
+func containedValue(val interface{}, asMap bool) (containers []ContainerKind, leaf interface{}) {
+	if val == nil {
+		return nil, nil
+	}
+
+	var kind ContainerKind
+	var elements []interface{}
+	switch reflect.TypeOf(val).Kind() {
+	case reflect.Array, reflect.Slice:
+		kind = ArrayContainer
 		arrayVal := reflect.ValueOf(val)
-		if arrayVal.Len() > 0 {
-			p.extractType(attributes, arrayVal.Index(0).Interface())
+		for i := 0; i < arrayVal.Len(); i++ {
+			elements = append(elements, arrayVal.Index(i).Interface())
+		}
+	case reflect.Map:
+		if !asMap {
+			return nil, val
+		}
+		kind = MapContainer
+		asMap = false
+		mapVal := val.(map[string]interface{})
+		for _, key := range sortedKeys(mapVal) {
+			elements = append(elements, mapVal[key])
 		}
 	default:
-		p.Type = value.String()
+		return nil, val
+	}
+
+	var innerContainers []ContainerKind
+	for i, element := range elements {
+		elementContainers, elementLeaf := containedValue(element, asMap)
+		if i == 0 || elementLeaf != nil {
+			innerContainers = elementContainers
+		}
+		if elementLeaf != nil {
+			leaf = elementLeaf
+			break
+		}
+	}
+	return append([]ContainerKind{kind}, innerContainers...), leaf
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
+	return keys
+}
+
+// applyForcedType overrides the type extracted from the value with the one in the attributes
+func (p *property) applyForcedType(attributes propertyAttributes) {
 	if attributes.forcedType != "" {
 		p.Type = attributes.forcedType
 	}
+	if attributes.dateFormat != "" {
+		p.Type = typeDate
+		p.DateFormat = attributes.dateFormat
+	}
+	if p.isDate() && p.DateFormat == "" {
+		p.DateFormat = dateFormatRFC3339
+	}
+	if !p.isDate() {
+		p.DateFormat = ""
+		p.DateInString = false
+	}
 	p.TypeLabel = p.Type
-	p.IsMap = attributes.forceAsMap
+}
+
+// numberType returns the narrowest type that can hold the number
+func numberType(number json.Number) string {
+	n, err := number.Int64()
+	if err != nil {
+		return typeFloat64
+	}
+	if n < math.MinInt32 || n > math.MaxInt32 {
+		return typeInt64
+	}
+	return typeInt
+}
+
+// hasUnknownType returns whether the type couldn't be inferred from the value (empty arrays)
+func (p property) hasUnknownType() bool {
+	return p.Type == ""
+}
+
+// isUntypedNull returns whether the property only has been found with a null value
+func (p property) isUntypedNull() bool {
+	return p.IsNullable && p.hasUnknownType() && !p.IsArray
+}
+
+// conflictsWith returns whether both properties have a known but different type
+func (p property) conflictsWith(other property) bool {
+	if p.IsRaw != other.IsRaw {
+		// A raw property can only be completed by nulls not declared as raw
+		nonRaw := p
+		if p.IsRaw {
+			nonRaw = other
+		}
+		return !nonRaw.isUntypedNull()
+	}
+	if p.isUntypedNull() || other.isUntypedNull() {
+		return false
+	}
+	if p.IsRaw {
+		return p.IsArray != other.IsArray || p.IsMap != other.IsMap
+	}
+	if p.hasUnknownType() || other.hasUnknownType() {
+		return p.IsArray != other.IsArray
+	}
+	if !sameContainers(p.Containers, other.Containers) {
+		return true
+	}
+	// Different numeric types are not a conflict. The widest one is used
+	if p.isNumeric() && other.isNumeric() {
+		return false
+	}
+	// Dates can be found as plain strings or numbers in some examples
+	if (p.isDate() && other.canHoldDate()) || (other.isDate() && p.canHoldDate()) {
+		return false
+	}
+	return p.Type != other.Type || p.DateFormat != other.DateFormat
+}
+
+func sameContainers(a, b []ContainerKind) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (p property) isNumeric() bool {
+	_, isNumeric := numericTypeWidth[p.Type]
+	return isNumeric
+}
+
+// mergedWith returns the result of completing this property with the
+// information of the same property found in another example
+func (p property) mergedWith(other property) property {
+	merged := p
+	if p.hasUnknownType() && (!other.hasUnknownType() || other.IsArray || other.IsMap) {
+		merged = other
+	}
+	if p.isNumeric() && other.isNumeric() && numericTypeWidth[other.Type] > numericTypeWidth[p.Type] {
+		merged.Type = other.Type
+		merged.TypeLabel = other.TypeLabel
+	}
+	if other.isDate() && !p.isDate() {
+		merged.Type = other.Type
+		merged.TypeLabel = other.TypeLabel
+		merged.DateFormat = other.DateFormat
+		merged.DateInString = other.DateInString
+	}
+	merged.IsRaw = p.IsRaw || other.IsRaw
+	merged.IsNullable = p.IsNullable || other.IsNullable
+	merged.isModel = p.isModel || other.isModel
+	merged.examplesCount = p.examplesCount + other.examplesCount
+	return merged
+}
+
+func (p property) typeDescription() string {
+	if p.IsRaw {
+		switch {
+		case p.IsArray:
+			return "raw array"
+		case p.IsMap:
+			return "raw map"
+		default:
+			return "raw value"
+		}
+	}
+	description := p.Type
+	if p.hasUnknownType() {
+		description = "unknown"
+	}
+	for i := len(p.Containers) - 1; i >= 0; i-- {
+		switch p.Containers[i] {
+		case ArrayContainer:
+			description = "array of " + description
+		case MapContainer:
+			description = "map of " + description
+		}
+	}
+	return description
 }
 
 type propertyAttributes struct {
@@ -160,6 +561,11 @@ type propertyAttributes struct {
 	forcedType string
 	forceAsMap bool
 	raw        bool
+	dateFormat string
+	enumValues []string
+	extends    string
+
+	discriminator string
 }
 
 func newPropertyAttributes(propertySpec string) (res propertyAttributes) {
@@ -184,11 +590,82 @@ func newPropertyAttributes(propertySpec string) (res propertyAttributes) {
 			res.forceAsMap = true
 		case attrKeyRaw:
 			res.raw = true
+		case attrKeyFormat:
+			res.dateFormat = strings.TrimSpace(val)
+		case attrKeyEnum:
+			res.enumValues = enumValuesFromSpec(val)
+		case attrKeyExtends:
+			res.extends = strings.TrimSpace(val)
+		case attrKeyDiscriminator:
+			res.discriminator = strings.TrimSpace(val)
 		}
 	}
 	return
 }
 
+// inheritFrom fills the attributes not specified with the ones passed. This allows
+// to specify the attributes of a property only in one of the examples
+func (attrs propertyAttributes) inheritFrom(other propertyAttributes) propertyAttributes {
+	if attrs.nameLabel == "" {
+		attrs.nameLabel = other.nameLabel
+	}
+	if attrs.forcedType == "" {
+		attrs.forcedType = other.forcedType
+	}
+	if attrs.dateFormat == "" {
+		attrs.dateFormat = other.dateFormat
+	}
+	if attrs.enumValues == nil {
+		attrs.enumValues = other.enumValues
+	}
+	if attrs.extends == "" {
+		attrs.extends = other.extends
+	}
+	if attrs.discriminator == "" {
+		attrs.discriminator = other.discriminator
+	}
+	attrs.forceAsMap = attrs.forceAsMap || other.forceAsMap
+	attrs.raw = attrs.raw || other.raw
+	return attrs
+}
+
+// lacksAnyOf returns whether the other attributes declare something these ones don't
+func (attrs propertyAttributes) lacksAnyOf(other propertyAttributes) bool {
+	return (attrs.nameLabel == "" && other.nameLabel != "") ||
+		(attrs.forcedType == "" && other.forcedType != "") ||
+		(attrs.dateFormat == "" && other.dateFormat != "") ||
+		(attrs.enumValues == nil && other.enumValues != nil) ||
+		(attrs.extends == "" && other.extends != "") ||
+		(attrs.discriminator == "" && other.discriminator != "") ||
+		(!attrs.forceAsMap && other.forceAsMap) ||
+		(!attrs.raw && other.raw)
+}
+
+// withLaterAttributes returns the property typed with the attributes, which include the ones declared
+// in a later example, as if all of them had been declared in the first one
+func (p property) withLaterAttributes(attributes propertyAttributes) property {
+	p.attributes = attributes
+	if attributes.nameLabel != "" {
+		p.NameLabel = attributes.nameLabel
+	}
+	if attributes.raw {
+		if !p.IsRaw {
+			p.IsRaw = true
+			p.IsMap = p.IsMap || p.isModel
+			p.Type = ""
+			p.TypeLabel = ""
+			p.Containers = nil
+			p.isModel = false
+		}
+		return p
+	}
+	if p.hasUnknownType() && attributes.forcedType != "" {
+		p.isModel = !isBasicType(attributes.forcedType)
+	}
+	p.applyForcedType(attributes)
+	return p
+}
+
 type endpointInfo struct {
 	ResourceModel  *modelInfo
 	RequestModel   *modelInfo
@@ -197,11 +674,23 @@ type endpointInfo struct {
 	Method         parser.HTTPMethod
 	URLPath        string
 	URLQueryParams url.Values
-	SegmentParams  []string
+	SegmentParams  []segmentParam
+	RequestFormat  RequestFormat
 	ResponseKind   ResponseKind
+	// MethodResourceName overrides the resource part of the service method name
+	MethodResourceName string
+	// Pagination is how to iterate the pages of the endpoint. It is nil if it is not paginated
+	Pagination *pagination
+	// Envelope is the object wrapping the response payload. It is nil if the payload is not wrapped
+	Envelope *envelope
+
+	crudName string
 }
 
 func (epi endpointInfo) CRUDMethodName() (string, error) {
+	if epi.crudName != "" {
+		return epi.crudName, nil
+	}
 	if epi.Method == parser.UNKNOWN_HTTP_METHOD {
 		return "", errors.Errorf("Unknown http method for endopint %s", epi.URLPath)
 	}
@@ -241,6 +730,23 @@ func (epi endpointInfo) IsRawMapResponse() bool {
 	return epi.ResponseKind == RawMapResponse
 }
 
+func (epi endpointInfo) IsTextResponse() bool {
+	return epi.ResponseKind == TextResponse
+}
+
+func (epi endpointInfo) IsBoolResponse() bool {
+	return epi.ResponseKind == BoolResponse
+}
+
+func (epi endpointInfo) IsBinaryResponse() bool {
+	return epi.ResponseKind == BinaryResponse
+}
+
+// HasJSONBodies returns whether both the request and the response are JSON
+func (epi endpointInfo) HasJSONBodies() bool {
+	return epi.RequestFormat == JSONRequest && epi.responseFormat() == contentJSON
+}
+
 func (epi endpointInfo) HasResponse() bool {
 	return epi.ResponseKind != EmptyResponse
 }
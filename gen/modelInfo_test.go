@@ -0,0 +1,301 @@
+package gen
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/alvaroloes/sdkgen/parser"
+	"github.com/alvaroloes/sdkgen/tests"
+	"github.com/kr/pretty"
+)
+
+type propertyInferenceTestCase struct {
+	name             string
+	api              *parser.API
+	expectedNullable map[string]bool
+	expectedOptional map[string]bool
+	expectedTypes    map[string]string
+}
+
+var propertyInferenceTestCases = []propertyInferenceTestCase{
+	{
+		name: "Null and missing properties",
+		api: &parser.API{
+			Endpoints: []parser.Endpoint{
+				{
+					Method:    parser.GET,
+					URL:       tests.MustParseURL("https://www.alvarloes.com/people"),
+					Resources: []parser.Resource{{Name: "people"}},
+					ResponseBody: []interface{}{
+						map[string]interface{}{"id": "1", "nick": nil, "age": json.Number("20"), "alias: type = string": nil},
+						map[string]interface{}{"id": "2", "nick": "Johnny"},
+					},
+				},
+			},
+		},
+		expectedNullable: map[string]bool{"id": false, "nick": true, "age": false, "alias": true},
+		expectedOptional: map[string]bool{"id": false, "nick": false, "age": true, "alias": true},
+		expectedTypes:    map[string]string{"id": "string", "nick": "string", "age": "int", "alias": "string"},
+	}, {
+		name: "Number types",
+		api: &parser.API{
+			Endpoints: []parser.Endpoint{
+				{
+					Method:    parser.GET,
+					URL:       tests.MustParseURL("https://www.alvarloes.com/people"),
+					Resources: []parser.Resource{{Name: "people"}},
+					ResponseBody: []interface{}{
+						map[string]interface{}{
+							"id":                    json.Number("1"),
+							"followers":             json.Number("3000000000"),
+							"height":                json.Number("1.80"),
+							"weight":                json.Number("80"),
+							"score: type = float64": json.Number("10"),
+						},
+						map[string]interface{}{
+							"id":        json.Number("2"),
+							"followers": json.Number("1"),
+							"height":    json.Number("2"),
+							"weight":    json.Number("80.5"),
+							"score":     json.Number("9"),
+						},
+					},
+				},
+			},
+		},
+		expectedTypes: map[string]string{"id": "int", "followers": "int64", "height": "float64", "weight": "float64", "score": "float64"},
+	},
+}
+
+func TestPropertyInference(t *testing.T) {
+	for _, testCase := range propertyInferenceTestCases {
+		gen, ok := extractTestModelsInfo(t, testCase.name, testCase.api, Config{}, nil)
+		if !ok {
+			continue
+		}
+
+		props := gen.modelsInfo["person"].Properties
+		for propName, nullable := range testCase.expectedNullable {
+			if props[propName].IsNullable != nullable {
+				t.Errorf("Test %q: Expected property %q nullable to be %v", testCase.name, propName, nullable)
+			}
+		}
+		for propName, optional := range testCase.expectedOptional {
+			if props[propName].IsOptional != optional {
+				t.Errorf("Test %q: Expected property %q optional to be %v", testCase.name, propName, optional)
+			}
+		}
+		for propName, propType := range testCase.expectedTypes {
+			if props[propName].Type != propType {
+				t.Errorf("Test %q: Expected property %q type %q, got: %q", testCase.name, propName, propType, props[propName].Type)
+			}
+		}
+	}
+}
+
+type propertyContainersTestCase struct {
+	name                string
+	api                 *parser.API
+	expectedContainers  map[string][]ContainerKind
+	expectedTypes       map[string]string
+	expectedModelsProps map[string][]string
+}
+
+var propertyContainersTestCases = []propertyContainersTestCase{
+	{
+		name: "Nested arrays and maps",
+		api: &parser.API{
+			Endpoints: []parser.Endpoint{
+				{
+					Method:    parser.GET,
+					URL:       tests.MustParseURL("https://www.alvarloes.com/classrooms/:id"),
+					Resources: []parser.Resource{{Name: "classrooms", Parameters: []string{"id"}}},
+					ResponseBody: map[string]interface{}{
+						"seats": []interface{}{
+							[]interface{}{},
+							[]interface{}{map[string]interface{}{"row": json.Number("1")}},
+							[]interface{}{map[string]interface{}{"column": json.Number("2")}},
+						},
+						"tags":                    []interface{}{[]interface{}{"a", "b"}},
+						"marks: map":              map[string]interface{}{"math": []interface{}{json.Number("10")}},
+						"teachers: map":           map[string]interface{}{"math": map[string]interface{}{"name": "Ann"}},
+						"groups":                  []interface{}{map[string]interface{}{"name": "A"}},
+						"matrix":                  []interface{}{[]interface{}{}},
+						"subjects: map":           nil,
+						"timetable: map":          map[string]interface{}{"monday": []interface{}{[]interface{}{map[string]interface{}{"name": "Math"}}}},
+						"updates":                 []interface{}{[]interface{}{"2016-03-06T21:28:18Z"}},
+						"schedule: type = period": nil,
+					},
+				},
+			},
+		},
+		expectedContainers: map[string][]ContainerKind{
+			"seats":     {ArrayContainer, ArrayContainer},
+			"tags":      {ArrayContainer, ArrayContainer},
+			"marks":     {MapContainer, ArrayContainer},
+			"teachers":  {MapContainer},
+			"groups":    {ArrayContainer},
+			"matrix":    {ArrayContainer, ArrayContainer},
+			"subjects":  {MapContainer},
+			"timetable": {MapContainer, ArrayContainer, ArrayContainer},
+			"updates":   {ArrayContainer, ArrayContainer},
+			"schedule":  nil,
+		},
+		expectedTypes: map[string]string{
+			"seats":     "seat",
+			"tags":      "string",
+			"marks":     "int",
+			"teachers":  "teacher",
+			"groups":    "group",
+			"matrix":    "",
+			"timetable": "timetable",
+			"updates":   "date",
+			"schedule":  "period",
+		},
+		expectedModelsProps: map[string][]string{
+			"classroom": {"groups", "marks", "matrix", "schedule", "seats", "subjects", "tags", "teachers", "timetable", "updates"},
+			"seat":      {"column", "row"},
+			"teacher":   {"name"},
+			"group":     {"name"},
+			"timetable": {"name"},
+			"period":    {},
+		},
+	},
+}
+
+func TestPropertyContainers(t *testing.T) {
+	for _, testCase := range propertyContainersTestCases {
+		gen, ok := extractTestModelsInfo(t, testCase.name, testCase.api, Config{}, nil)
+		if !ok {
+			continue
+		}
+
+		props := gen.modelsInfo["classroom"].Properties
+		containers := map[string][]ContainerKind{}
+		for propName := range testCase.expectedContainers {
+			containers[propName] = props[propName].Containers
+		}
+		if diffs := pretty.Diff(testCase.expectedContainers, containers); len(diffs) > 0 {
+			t.Errorf("Test %q: Didn't get the expected containers. Differences are:\n%v", testCase.name, tests.FormattedDiff(diffs))
+		}
+		for propName, propType := range testCase.expectedTypes {
+			if props[propName].Type != propType {
+				t.Errorf("Test %q: Expected property %q type %q, got: %q", testCase.name, propName, propType, props[propName].Type)
+			}
+		}
+
+		modelsProps := map[string][]string{}
+		for modelName, mInfo := range gen.modelsInfo {
+			modelsProps[modelName] = []string{}
+			for propName := range mInfo.Properties {
+				modelsProps[modelName] = append(modelsProps[modelName], propName)
+			}
+			sort.Strings(modelsProps[modelName])
+		}
+		if diffs := pretty.Diff(testCase.expectedModelsProps, modelsProps); len(diffs) > 0 {
+			t.Errorf("Test %q: Didn't get the expected models. Differences are:\n%v", testCase.name, tests.FormattedDiff(diffs))
+		}
+	}
+}
+
+type rawPropertiesTestCase struct {
+	name              string
+	api               *parser.API
+	expectedRaw       map[string]bool
+	expectedIsArray   map[string]bool
+	expectedIsMap     map[string]bool
+	expectedModels    []string
+	expectedConflicts int
+}
+
+var rawPropertiesTestCases = []rawPropertiesTestCase{
+	{
+		name: "Raw maps, arrays and values",
+		api: &parser.API{
+			Endpoints: []parser.Endpoint{
+				{
+					Method:    parser.GET,
+					URL:       tests.MustParseURL("https://www.alvarloes.com/people/:id"),
+					Resources: []parser.Resource{{Name: "people", Parameters: []string{"id"}}},
+					ResponseBody: map[string]interface{}{
+						"metadata: raw":                 map[string]interface{}{"source": map[string]interface{}{"name": "web"}},
+						"history: raw":                  []interface{}{map[string]interface{}{"action": "login"}, []interface{}{}},
+						"extra: raw":                    json.Number("3"),
+						"settings: raw; map":            nil,
+						"friend":                        map[string]interface{}{"name": "John"},
+						"preferences: raw":              nil,
+						"lastSession: raw":              map[string]interface{}{"device": "phone"},
+						"lastSession2: raw":             []interface{}{},
+						"counters: raw; type = counter": map[string]interface{}{"views": json.Number("1")},
+					},
+				},
+				{
+					Method:    parser.GET,
+					URL:       tests.MustParseURL("https://www.alvarloes.com/people"),
+					Resources: []parser.Resource{{Name: "people"}},
+					ResponseBody: []interface{}{
+						map[string]interface{}{
+							"metadata":     map[string]interface{}{"source": "app"},
+							"settings":     map[string]interface{}{"theme": "dark"},
+							"preferences":  []interface{}{"a"},
+							"lastSession2": map[string]interface{}{"device": "phone"},
+						},
+					},
+				},
+			},
+		},
+		expectedRaw: map[string]bool{
+			"metadata": true, "history": true, "extra": true, "settings": true, "friend": false,
+			"preferences": true, "lastSession": true, "lastSession2": true, "counters": true,
+		},
+		expectedIsArray: map[string]bool{
+			"metadata": false, "history": true, "extra": false, "settings": false,
+			"preferences": true, "lastSession2": true,
+		},
+		expectedIsMap: map[string]bool{
+			"metadata": true, "history": false, "extra": false, "settings": true,
+			"preferences": false, "lastSession": true,
+		},
+		expectedModels:    []string{"friend", "person"},
+		expectedConflicts: 1,
+	},
+}
+
+func TestRawProperties(t *testing.T) {
+	for _, testCase := range rawPropertiesTestCases {
+		gen, ok := extractTestModelsInfo(t, testCase.name, testCase.api, Config{}, nil)
+		if !ok {
+			continue
+		}
+
+		props := gen.modelsInfo["person"].Properties
+		for propName, raw := range testCase.expectedRaw {
+			if props[propName].IsRaw != raw {
+				t.Errorf("Test %q: Expected property %q raw to be %v", testCase.name, propName, raw)
+			}
+		}
+		for propName, isArray := range testCase.expectedIsArray {
+			if props[propName].IsArray != isArray {
+				t.Errorf("Test %q: Expected property %q IsArray to be %v", testCase.name, propName, isArray)
+			}
+		}
+		for propName, isMap := range testCase.expectedIsMap {
+			if props[propName].IsMap != isMap {
+				t.Errorf("Test %q: Expected property %q IsMap to be %v", testCase.name, propName, isMap)
+			}
+		}
+
+		var models []string
+		for modelName := range gen.modelsInfo {
+			models = append(models, modelName)
+		}
+		sort.Strings(models)
+		if diffs := pretty.Diff(testCase.expectedModels, models); len(diffs) > 0 {
+			t.Errorf("Test %q: Didn't get the expected models. Differences are:\n%v", testCase.name, tests.FormattedDiff(diffs))
+		}
+		if len(gen.propertyConflicts) != testCase.expectedConflicts {
+			t.Errorf("Test %q: Expected %d conflicts, got: %v", testCase.name, testCase.expectedConflicts, conflictsString(gen.propertyConflicts))
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package gen
+
+import (
+	"sort"
+
+	"github.com/jinzhu/inflection"
+	"github.com/juju/errors"
+)
+
+// setModelParent makes the model extend the parent one. Nothing is done if the parent name is empty
+func (g *Generator) setModelParent(modelName, parentName string) error {
+	if parentName == "" {
+		return nil
+	}
+	mInfo := g.getModelOrCreate(modelName)
+	parent := g.getModelOrCreate(parentName)
+	if parent == mInfo {
+		return errors.Annotatef(ErrInvalidInheritance, "model %q can't extend itself", mInfo.Name)
+	}
+	if mInfo.Parent != nil && mInfo.Parent != parent {
+		return errors.Annotatef(ErrInvalidInheritance, "model %q extends %q and %q", mInfo.Name, mInfo.Parent.Name, parent.Name)
+	}
+	mInfo.Parent = parent
+	return nil
+}
+
+// resolveModelsInheritance moves the properties of the base models out of the models extending them.
+// A base model that is not found in any example is made of the properties shared by all its children
+func (g *Generator) resolveModelsInheritance() error {
+	depths := map[*modelInfo]int{}
+	childrenPerModel := map[*modelInfo][]*modelInfo{}
+	var parents []*modelInfo
+	// Iterate in name order so that the result doesn't depend on the map iteration order
+	modelNames := make([]string, 0, len(g.modelsInfo))
+	for modelName := range g.modelsInfo {
+		modelNames = append(modelNames, modelName)
+	}
+	sort.Strings(modelNames)
+	for _, modelName := range modelNames {
+		mInfo := g.modelsInfo[modelName]
+		depth := 0
+		for parent := mInfo.Parent; parent != nil; parent = parent.Parent {
+			depth++
+			if depth > len(g.modelsInfo) {
+				return errors.Annotatef(ErrInvalidInheritance, "model %q is part of an inheritance cycle", mInfo.Name)
+			}
+		}
+		depths[mInfo] = depth
+		if mInfo.Parent != nil {
+			mInfo.Parent.hasChildren = true
+			if len(childrenPerModel[mInfo.Parent]) == 0 {
+				parents = append(parents, mInfo.Parent)
+			}
+			childrenPerModel[mInfo.Parent] = append(childrenPerModel[mInfo.Parent], mInfo)
+		}
+	}
+
+	// The deepest base models go first, so that the properties bubble up through all the hierarchy
+	sort.Stable(modelsByDepth{models: parents, depths: depths})
+	for _, parent := range parents {
+		children := childrenPerModel[parent]
+		if parent.examplesCount == 0 && len(parent.Properties) == 0 {
+			parent.Properties = sharedProperties(children)
+			if parent.Discriminator != "" && len(children) == 1 {
+				// With a single variant, all its properties would be shared. Only the discriminator is
+				parent.Properties = onlyProperty(parent.Properties, parent.Discriminator)
+			}
+		}
+		for _, child := range children {
+			if err := moveInheritedProperties(parent, child); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// sharedProperties returns the properties found with the same type in all the models
+func sharedProperties(models []*modelInfo) map[string]property {
+	shared := map[string]property{}
+	for propName, prop := range models[0].Properties {
+		isShared := true
+		for _, mInfo := range models[1:] {
+			otherProp, found := mInfo.Properties[propName]
+			if !found || prop.conflictsWith(otherProp) {
+				isShared = false
+				break
+			}
+			prop = prop.mergedWith(otherProp)
+			prop.IsOptional = prop.IsOptional || otherProp.IsOptional
+		}
+		if isShared {
+			shared[propName] = prop
+		}
+	}
+	return shared
+}
+
+func onlyProperty(props map[string]property, propName string) map[string]property {
+	res := map[string]property{}
+	if prop, found := props[propName]; found {
+		res[propName] = prop
+	}
+	return res
+}
+
+// moveInheritedProperties removes from the child the properties of its parent, which is
+// updated with the dependencies of those properties
+func moveInheritedProperties(parent, child *modelInfo) error {
+	for propName, parentProp := range parent.Properties {
+		childProp, found := child.Properties[propName]
+		if !found {
+			continue
+		}
+		if parentProp.conflictsWith(childProp) {
+			return errors.Annotatef(ErrInvalidInheritance, "property %q of model %q is %s but %s in its base model %q",
+				propName, child.Name, childProp.typeDescription(), parentProp.typeDescription(), parent.Name)
+		}
+		optional := parentProp.IsOptional || childProp.IsOptional
+		parentProp = parentProp.mergedWith(childProp)
+		parentProp.IsOptional = optional
+		parent.Properties[propName] = parentProp
+		delete(child.Properties, propName)
+
+		if parentProp.isModel {
+			for dep := range child.ModelDependencies {
+				if dep.Name == inflection.Singular(parentProp.Type) {
+					parent.ModelDependencies[dep] = struct{}{}
+				}
+			}
+		}
+		if parentProp.Enum != nil {
+			parent.EnumDependencies[parentProp.Enum] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// modelsByDepth sorts the models from the deepest in the inheritance hierarchy to the shallowest
+type modelsByDepth struct {
+	models []*modelInfo
+	depths map[*modelInfo]int
+}
+
+func (m modelsByDepth) Len() int           { return len(m.models) }
+func (m modelsByDepth) Less(i, j int) bool { return m.depths[m.models[i]] > m.depths[m.models[j]] }
+func (m modelsByDepth) Swap(i, j int)      { m.models[i], m.models[j] = m.models[j], m.models[i] }
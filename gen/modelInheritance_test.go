@@ -0,0 +1,199 @@
+package gen
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/alvaroloes/sdkgen/parser"
+	"github.com/alvaroloes/sdkgen/tests"
+	"github.com/kr/pretty"
+)
+
+type modelsInheritanceTestCase struct {
+	name            string
+	api             *parser.API
+	expectedError   error
+	expectedParents map[string]string
+	expectedProps   map[string][]string
+}
+
+var modelsInheritanceTestCases = []modelsInheritanceTestCase{
+	{
+		name: "Base model made of the shared properties",
+		api: &parser.API{
+			Endpoints: []parser.Endpoint{
+				{
+					Method:       parser.GET,
+					URL:          tests.MustParseURL("https://www.alvarloes.com/people/:id"),
+					Resources:    []parser.Resource{{Name: "people", Parameters: []string{"id"}}},
+					ResponseSpec: "extends = resource",
+					ResponseBody: map[string]interface{}{
+						"id":        json.Number("1"),
+						"createdAt": "2016-03-06T21:28:18Z",
+						"name":      "John",
+						"owner: extends = resource": map[string]interface{}{
+							"id":    json.Number("2"),
+							"login": "admin",
+						},
+					},
+				},
+				{
+					Method:       parser.GET,
+					URL:          tests.MustParseURL("https://www.alvarloes.com/posts/:id"),
+					Resources:    []parser.Resource{{Name: "posts", Parameters: []string{"id"}}},
+					ResponseSpec: "extends = resource",
+					ResponseBody: map[string]interface{}{
+						"id":        json.Number("3"),
+						"createdAt": "2016-03-06T21:28:18Z",
+						"title":     "Hello",
+					},
+				},
+			},
+		},
+		expectedParents: map[string]string{"person": "resource", "owner": "resource", "post": "resource", "resource": ""},
+		expectedProps: map[string][]string{
+			"resource": {"id"},
+			"person":   {"createdAt", "name", "owner"},
+			"owner":    {"login"},
+			"post":     {"createdAt", "title"},
+		},
+	}, {
+		name: "Base model with its own examples and several levels",
+		api: &parser.API{
+			Endpoints: []parser.Endpoint{
+				{
+					Method:    parser.GET,
+					URL:       tests.MustParseURL("https://www.alvarloes.com/resources/:id"),
+					Resources: []parser.Resource{{Name: "resources", Parameters: []string{"id"}}},
+					ResponseBody: map[string]interface{}{
+						"id": json.Number("1"),
+					},
+				},
+				{
+					Method:       parser.GET,
+					URL:          tests.MustParseURL("https://www.alvarloes.com/users/:id"),
+					Resources:    []parser.Resource{{Name: "users", Parameters: []string{"id"}}},
+					ResponseSpec: "extends = resource",
+					ResponseBody: map[string]interface{}{
+						"id":    json.Number("1"),
+						"email": "john@alvarloes.com",
+					},
+				},
+				{
+					Method:       parser.GET,
+					URL:          tests.MustParseURL("https://www.alvarloes.com/admins/:id"),
+					Resources:    []parser.Resource{{Name: "admins", Parameters: []string{"id"}}},
+					ResponseSpec: "extends = user",
+					ResponseBody: map[string]interface{}{
+						"id":    json.Number("1"),
+						"email": "admin@alvarloes.com",
+						"level": json.Number("1"),
+					},
+				},
+			},
+		},
+		expectedParents: map[string]string{"resource": "", "user": "resource", "admin": "user"},
+		expectedProps: map[string][]string{
+			"resource": {"id"},
+			"user":     {"email"},
+			"admin":    {"level"},
+		},
+	}, {
+		name: "Property conflicting with the base model",
+		api: &parser.API{
+			Endpoints: []parser.Endpoint{
+				{
+					Method:    parser.GET,
+					URL:       tests.MustParseURL("https://www.alvarloes.com/resources/:id"),
+					Resources: []parser.Resource{{Name: "resources", Parameters: []string{"id"}}},
+					ResponseBody: map[string]interface{}{
+						"id": json.Number("1"),
+					},
+				},
+				{
+					Method:       parser.GET,
+					URL:          tests.MustParseURL("https://www.alvarloes.com/users/:id"),
+					Resources:    []parser.Resource{{Name: "users", Parameters: []string{"id"}}},
+					ResponseSpec: "extends = resource",
+					ResponseBody: map[string]interface{}{
+						"id": "1",
+					},
+				},
+			},
+		},
+		expectedError: ErrInvalidInheritance,
+	}, {
+		name: "Inheritance cycle",
+		api: &parser.API{
+			Endpoints: []parser.Endpoint{
+				{
+					Method:       parser.GET,
+					URL:          tests.MustParseURL("https://www.alvarloes.com/users/:id"),
+					Resources:    []parser.Resource{{Name: "users", Parameters: []string{"id"}}},
+					ResponseSpec: "extends = admin",
+					ResponseBody: map[string]interface{}{"id": json.Number("1")},
+				},
+				{
+					Method:       parser.GET,
+					URL:          tests.MustParseURL("https://www.alvarloes.com/admins/:id"),
+					Resources:    []parser.Resource{{Name: "admins", Parameters: []string{"id"}}},
+					ResponseSpec: "extends = user",
+					ResponseBody: map[string]interface{}{"id": json.Number("1")},
+				},
+			},
+		},
+		expectedError: ErrInvalidInheritance,
+	}, {
+		name: "Several base models",
+		api: &parser.API{
+			Endpoints: []parser.Endpoint{
+				{
+					Method:       parser.GET,
+					URL:          tests.MustParseURL("https://www.alvarloes.com/users/:id"),
+					Resources:    []parser.Resource{{Name: "users", Parameters: []string{"id"}}},
+					ResponseSpec: "extends = resource",
+					ResponseBody: map[string]interface{}{"id": json.Number("1")},
+				},
+				{
+					Method:       parser.PUT,
+					URL:          tests.MustParseURL("https://www.alvarloes.com/users/:id"),
+					Resources:    []parser.Resource{{Name: "users", Parameters: []string{"id"}}},
+					RequestSpec:  "extends = entity",
+					RequestBody:  map[string]interface{}{"id": json.Number("1")},
+					ResponseBody: map[string]interface{}{"id": json.Number("1")},
+				},
+			},
+		},
+		expectedError: ErrInvalidInheritance,
+	},
+}
+
+func TestModelsInheritance(t *testing.T) {
+	for _, testCase := range modelsInheritanceTestCases {
+		gen, ok := extractTestModelsInfo(t, testCase.name, testCase.api, Config{}, testCase.expectedError)
+		if !ok {
+			continue
+		}
+
+		parents := map[string]string{}
+		modelsProps := map[string][]string{}
+		for modelName, mInfo := range gen.modelsInfo {
+			parents[modelName] = ""
+			if mInfo.Parent != nil {
+				parents[modelName] = mInfo.Parent.Name
+			}
+			modelsProps[modelName] = []string{}
+			for propName := range mInfo.Properties {
+				modelsProps[modelName] = append(modelsProps[modelName], propName)
+			}
+			sort.Strings(modelsProps[modelName])
+		}
+		if diffs := pretty.Diff(testCase.expectedParents, parents); len(diffs) > 0 {
+			t.Errorf("Test %q: Didn't get the expected parents. Differences are:\n%v", testCase.name, tests.FormattedDiff(diffs))
+		}
+		if diffs := pretty.Diff(testCase.expectedProps, modelsProps); len(diffs) > 0 {
+			t.Errorf("Test %q: Didn't get the expected properties. Differences are:\n%v", testCase.name, tests.FormattedDiff(diffs))
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package gen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/jinzhu/inflection"
+	"github.com/juju/errors"
+)
+
+//go:generate enumer -type=ModelNamesStrategy
+
+// ModelNamesStrategy is how the nested models found with the same name but different shapes are named
+type ModelNamesStrategy int
+
+const (
+	// KeepModelNames merges the colliding models into one, reporting the collision
+	KeepModelNames ModelNamesStrategy = iota
+	// ParentPrefixedModelNames prefixes the name of the colliding models with the name of the model containing them
+	ParentPrefixedModelNames
+)
+
+const modelNamesPathSeparator = "."
+
+// modelNameCollision represents two objects with the same model name but different
+// shapes found in different places of the spec: a property with different types, or different
+// properties in the objects of two properties
+type modelNameCollision struct {
+	ModelName string
+	// Existing and Found are the property with different types or, if the properties of the objects
+	// are set, the properties containing the objects
+	Existing property
+	Found    property
+	// ExistingProperties and FoundProperties are the properties of the objects, sorted
+	ExistingProperties []string
+	FoundProperties    []string
+}
+
+func (c modelNameCollision) String() string {
+	if c.ExistingProperties == nil {
+		return fmt.Sprintf("model %q has different shapes: property %q is %s in %s%s but %s in %s%s",
+			c.ModelName, c.Existing.Name,
+			c.Existing.typeDescription(), c.Existing.location, originDescription(c.Existing.location),
+			c.Found.typeDescription(), c.Found.location, originDescription(c.Found.location))
+	}
+	modelName := fmt.Sprintf("%q", c.ModelName)
+	if c.Existing.Name != c.Found.Name {
+		modelName += fmt.Sprintf(" (singular of %q and %q)", c.Existing.Name, c.Found.Name)
+	}
+	return fmt.Sprintf("model %s has different shapes: its objects have properties %q in %s but %q in %s",
+		modelName, c.ExistingProperties, c.Existing.location, c.FoundProperties, c.Found.location)
+}
+
+// modelOrigin is a property whose objects are of a model, along with the properties found in them
+type modelOrigin struct {
+	// origin is the model property, like "post.author"
+	origin    string
+	prop      property
+	propNames map[string]struct{}
+}
+
+// addModelOrigin records that the objects of the property are of its model, to find out later if the objects
+// found in other properties are different models with the same name. The models declared with a type
+// are always the same, so they are not recorded
+func (g *Generator) addModelOrigin(parent *modelInfo, prop property) {
+	if prop.attributes.forcedType != "" {
+		return
+	}
+	origin := prop.location.insideOf(parent.Name, prop.Name).origin
+	for _, mOrigin := range g.modelOrigins[prop.Type] {
+		if mOrigin.origin == origin {
+			return
+		}
+	}
+	g.modelOrigins[prop.Type] = append(g.modelOrigins[prop.Type], &modelOrigin{
+		origin:    origin,
+		prop:      prop,
+		propNames: map[string]struct{}{},
+	})
+}
+
+// addOriginProperties records the properties of an object of the model found in the origin
+func (g *Generator) addOriginProperties(modelName, origin string, props map[string]interface{}) {
+	for _, mOrigin := range g.modelOrigins[modelName] {
+		if mOrigin.origin == origin {
+			for propSpec := range props {
+				mOrigin.propNames[newPropertyAttributes(propSpec).name] = struct{}{}
+			}
+		}
+	}
+}
+
+// addModelShapeCollisions records the models whose objects have different properties in different
+// origins. When the origins are properties with the same name, the objects are only different if both
+// have properties the other doesn't, as some examples can be incomplete. When the names are different
+// (the singulars of both are the same), any difference is a collision
+func (g *Generator) addModelShapeCollisions() {
+	collidingModels := map[string]struct{}{}
+	for _, collision := range g.modelNameCollisions {
+		collidingModels[collision.ModelName] = struct{}{}
+	}
+	modelNames := make([]string, 0, len(g.modelOrigins))
+	for modelName := range g.modelOrigins {
+		modelNames = append(modelNames, modelName)
+	}
+	sort.Strings(modelNames)
+	for _, modelName := range modelNames {
+		if _, colliding := collidingModels[modelName]; colliding {
+			continue
+		}
+		origins := g.modelOrigins[modelName]
+		first := origins[0]
+		for _, other := range origins[1:] {
+			firstOnly := missingNames(first.propNames, other.propNames)
+			otherOnly := missingNames(other.propNames, first.propNames)
+			sameName := first.prop.Name == other.prop.Name
+			if (sameName && firstOnly > 0 && otherOnly > 0) || (!sameName && firstOnly+otherOnly > 0) {
+				g.modelNameCollisions = append(g.modelNameCollisions, modelNameCollision{
+					ModelName:          modelName,
+					Existing:           first.prop,
+					Found:              other.prop,
+					ExistingProperties: sortedNames(first.propNames),
+					FoundProperties:    sortedNames(other.propNames),
+				})
+				break
+			}
+		}
+	}
+}
+
+// missingNames returns how many names of the set are not in the other one
+func missingNames(names, otherNames map[string]struct{}) int {
+	missing := 0
+	for name := range names {
+		if _, found := otherNames[name]; !found {
+			missing++
+		}
+	}
+	return missing
+}
+
+func sortedNames(names map[string]struct{}) []string {
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+func originDescription(location specLocation) string {
+	if location.origin == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (inside %q)", location.origin)
+}
+
+func collisionsString(collisions []modelNameCollision) string {
+	descriptions := make([]string, 0, len(collisions))
+	for _, c := range collisions {
+		descriptions = append(descriptions, c.String())
+	}
+	return strings.Join(descriptions, "\n")
+}
+
+// modelName returns the name of the model, applying the renames of the config
+func (g *Generator) modelName(name string) string {
+	singularName := inflection.Singular(name)
+	if newName, found := g.config.ModelNames[singularName]; found {
+		return newName
+	}
+	return singularName
+}
+
+// nestedModelName returns the name of the model of a property, applying the renames of the config
+// and prefixing it with the name of the parent model if it collides with other model
+func (g *Generator) nestedModelName(parent *modelInfo, prop property) string {
+	if newName, found := g.config.ModelNames[parent.Name+modelNamesPathSeparator+prop.Name]; found {
+		return newName
+	}
+	name := g.modelName(prop.Type)
+	if _, prefixed := g.prefixedModelNames[name]; prefixed && prop.attributes.forcedType == "" {
+		name = camelCase(parent.Name + "_" + name)
+	}
+	return name
+}
+
+// prefixCollidingModelNames marks the colliding models to be prefixed with the name of their parent
+// model. It returns whether any new model name is marked
+func (g *Generator) prefixCollidingModelNames() bool {
+	newNames := false
+	for _, collision := range g.modelNameCollisions {
+		if _, prefixed := g.prefixedModelNames[collision.ModelName]; !prefixed {
+			g.prefixedModelNames[collision.ModelName] = struct{}{}
+			newNames = true
+		}
+	}
+	return newNames
+}
+
+func (g *Generator) reportModelNameCollisions() error {
+	if len(g.modelNameCollisions) == 0 {
+		return nil
+	}
+	if g.config.StrictPropertyTypes {
+		return errors.Annotate(ErrModelNameCollision, collisionsString(g.modelNameCollisions))
+	}
+	for _, collision := range g.modelNameCollisions {
+		log.Warn(collision.String() + ". Rename them or use the parent prefixed model names strategy")
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package gen
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/alvaroloes/sdkgen/parser"
+	"github.com/alvaroloes/sdkgen/tests"
+)
+
+var collidingAuthorsAPI = &parser.API{
+	Endpoints: []parser.Endpoint{
+		{
+			Method:    parser.GET,
+			URL:       tests.MustParseURL("https://www.alvarloes.com/posts/:id"),
+			Resources: []parser.Resource{{Name: "posts", Parameters: []string{"id"}}},
+			ResponseBody: map[string]interface{}{
+				"title":  "Hello",
+				"author": map[string]interface{}{"id": json.Number("1"), "name": "John"},
+			},
+		},
+		{
+			Method:    parser.GET,
+			URL:       tests.MustParseURL("https://www.alvarloes.com/books/:id"),
+			Resources: []parser.Resource{{Name: "books", Parameters: []string{"id"}}},
+			ResponseBody: map[string]interface{}{
+				"isbn":   "978-3-16-148410-0",
+				"author": map[string]interface{}{"id": "tolkien", "country": "UK"},
+			},
+		},
+		{
+			Method:    parser.GET,
+			URL:       tests.MustParseURL("https://www.alvarloes.com/authors/:id"),
+			Resources: []parser.Resource{{Name: "authors", Parameters: []string{"id"}}},
+			ResponseBody: map[string]interface{}{
+				"id":   json.Number("1"),
+				"name": "John",
+			},
+		},
+	},
+}
+
+// differentAuthorsAPI has authors with properties of the same types, but different ones
+var differentAuthorsAPI = &parser.API{
+	Endpoints: []parser.Endpoint{
+		{
+			Method:       parser.GET,
+			URL:          tests.MustParseURL("https://www.alvarloes.com/posts/:id"),
+			Resources:    []parser.Resource{{Name: "posts", Parameters: []string{"id"}}},
+			ResponseBody: map[string]interface{}{"author": map[string]interface{}{"id": "1", "name": "John"}},
+		},
+		{
+			Method:       parser.GET,
+			URL:          tests.MustParseURL("https://www.alvarloes.com/books/:id"),
+			Resources:    []parser.Resource{{Name: "books", Parameters: []string{"id"}}},
+			ResponseBody: map[string]interface{}{"author": map[string]interface{}{"id": "tolkien", "country": "UK"}},
+		},
+		{
+			Method:       parser.GET,
+			URL:          tests.MustParseURL("https://www.alvarloes.com/comments/:id"),
+			Resources:    []parser.Resource{{Name: "comments", Parameters: []string{"id"}}},
+			ResponseBody: map[string]interface{}{"author": map[string]interface{}{"id": "2"}},
+		},
+	},
+}
+
+type modelNamesTestCase struct {
+	name               string
+	api                *parser.API
+	config             Config
+	expectedError      error
+	expectedCollisions int
+	expectedTypes      map[string]map[string]string
+}
+
+var modelNamesTestCases = []modelNamesTestCase{
+	{
+		name:               "Colliding models are reported",
+		api:                collidingAuthorsAPI,
+		expectedCollisions: 1,
+		expectedTypes: map[string]map[string]string{
+			"post": {"author": "author"},
+			"book": {"author": "author"},
+		},
+	}, {
+		name:          "Colliding models fail in strict mode",
+		api:           collidingAuthorsAPI,
+		config:        Config{StrictPropertyTypes: true},
+		expectedError: ErrModelNameCollision,
+	}, {
+		name:   "Colliding models prefixed with the parent model name",
+		api:    collidingAuthorsAPI,
+		config: Config{ModelNamesStrategy: ParentPrefixedModelNames},
+		expectedTypes: map[string]map[string]string{
+			"post":       {"author": "postAuthor"},
+			"book":       {"author": "bookAuthor"},
+			"author":     {"id": "int"},
+			"postAuthor": {"id": "int"},
+			"bookAuthor": {"id": "string"},
+		},
+	}, {
+		name: "Renamed models",
+		api:  collidingAuthorsAPI,
+		config: Config{ModelNames: map[string]string{
+			"post":        "article",
+			"book.author": "writer",
+		}},
+		expectedTypes: map[string]map[string]string{
+			"article": {"author": "author"},
+			"book":    {"author": "writer"},
+			"writer":  {"id": "string"},
+			"author":  {"id": "int"},
+		},
+	}, {
+		name:               "Models with different properties are reported",
+		api:                differentAuthorsAPI,
+		expectedCollisions: 1,
+		expectedTypes: map[string]map[string]string{
+			"post":    {"author": "author"},
+			"book":    {"author": "author"},
+			"comment": {"author": "author"},
+		},
+	}, {
+		name:   "Models with different properties prefixed with the parent model name",
+		api:    differentAuthorsAPI,
+		config: Config{ModelNamesStrategy: ParentPrefixedModelNames},
+		expectedTypes: map[string]map[string]string{
+			"post":          {"author": "postAuthor"},
+			"book":          {"author": "bookAuthor"},
+			"comment":       {"author": "commentAuthor"},
+			"postAuthor":    {"name": "string"},
+			"bookAuthor":    {"country": "string"},
+			"commentAuthor": {"id": "string"},
+		},
+	}, {
+		name: "Different names with the same singular",
+		api: &parser.API{
+			Endpoints: []parser.Endpoint{
+				{
+					Method:       parser.GET,
+					URL:          tests.MustParseURL("https://www.alvarloes.com/posts/:id"),
+					Resources:    []parser.Resource{{Name: "posts", Parameters: []string{"id"}}},
+					ResponseBody: map[string]interface{}{"bases": []interface{}{map[string]interface{}{"city": "Rota"}}},
+				},
+				{
+					Method:       parser.GET,
+					URL:          tests.MustParseURL("https://www.alvarloes.com/books/:id"),
+					Resources:    []parser.Resource{{Name: "books", Parameters: []string{"id"}}},
+					ResponseBody: map[string]interface{}{"basis": map[string]interface{}{"theory": "Relativity"}},
+				},
+			},
+		},
+		expectedCollisions: 1,
+		expectedTypes: map[string]map[string]string{
+			"post": {"bases": "basis"},
+			"book": {"basis": "basis"},
+		},
+	}, {
+		name: "Incomplete examples of the same model",
+		api: &parser.API{
+			Endpoints: []parser.Endpoint{
+				{
+					Method:       parser.GET,
+					URL:          tests.MustParseURL("https://www.alvarloes.com/posts/:id"),
+					Resources:    []parser.Resource{{Name: "posts", Parameters: []string{"id"}}},
+					ResponseBody: map[string]interface{}{"author": map[string]interface{}{"id": "1", "name": "John"}},
+				},
+				{
+					Method:       parser.GET,
+					URL:          tests.MustParseURL("https://www.alvarloes.com/comments/:id"),
+					Resources:    []parser.Resource{{Name: "comments", Parameters: []string{"id"}}},
+					ResponseBody: map[string]interface{}{"author": map[string]interface{}{"id": "2"}},
+				},
+			},
+		},
+		expectedCollisions: 0,
+		expectedTypes: map[string]map[string]string{
+			"post":    {"author": "author"},
+			"comment": {"author": "author"},
+		},
+	},
+}
+
+func TestModelNames(t *testing.T) {
+	for _, testCase := range modelNamesTestCases {
+		gen, ok := extractTestModelsInfo(t, testCase.name, testCase.api, testCase.config, testCase.expectedError)
+		if !ok {
+			continue
+		}
+
+		if len(gen.modelNameCollisions) != testCase.expectedCollisions {
+			t.Errorf("Test %q: Expected %d collisions, got: %v", testCase.name, testCase.expectedCollisions, collisionsString(gen.modelNameCollisions))
+		}
+		if len(gen.propertyConflicts) > 0 {
+			t.Errorf("Test %q: Unexpected property conflicts: %v", testCase.name, conflictsString(gen.propertyConflicts))
+		}
+		for modelName, propTypes := range testCase.expectedTypes {
+			mInfo, found := gen.modelsInfo[modelName]
+			if !found {
+				t.Errorf("Test %q: Expected model %q", testCase.name, modelName)
+				continue
+			}
+			for propName, propType := range propTypes {
+				if mInfo.Properties[propName].Type != propType {
+					t.Errorf("Test %q: Expected property %q of model %q type %q, got: %q", testCase.name, propName, modelName, propType, mInfo.Properties[propName].Type)
+				}
+			}
+		}
+	}
+}
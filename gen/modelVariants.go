@@ -0,0 +1,49 @@
+package gen
+
+import (
+	"github.com/juju/errors"
+)
+
+// setModelDiscriminator declares the property that tells the variant of each object of the model.
+// Nothing is done if the discriminator is empty
+func (g *Generator) setModelDiscriminator(modelName, discriminator string) error {
+	if discriminator == "" {
+		return nil
+	}
+	mInfo := g.getModelOrCreate(modelName)
+	if mInfo.Discriminator != "" && mInfo.Discriminator != discriminator {
+		return errors.Annotatef(ErrInvalidDiscriminator, "model %q is discriminated by %q and %q", mInfo.Name, mInfo.Discriminator, discriminator)
+	}
+	mInfo.Discriminator = discriminator
+	return nil
+}
+
+// variantModel returns the variant of the model the object belongs to, creating it if needed.
+// It returns nil if the model has no discriminator or the object doesn't have a valid value for it,
+// in which case the object belongs to the model itself
+func (g *Generator) variantModel(mInfo *modelInfo, props map[string]interface{}) (*modelInfo, error) {
+	if mInfo.Discriminator == "" {
+		return nil, nil
+	}
+
+	var value string
+	for propSpec, propVal := range props {
+		if newPropertyAttributes(propSpec).name == mInfo.Discriminator {
+			value, _ = propVal.(string)
+			break
+		}
+	}
+	if value == "" {
+		return nil, nil
+	}
+
+	variant, found := mInfo.Variants[value]
+	if !found {
+		variant = g.getModelOrCreate(camelCase(value + "_" + mInfo.Name))
+		if err := g.setModelParent(variant.Name, mInfo.Name); err != nil {
+			return nil, errors.Annotatef(err, "for the variant %q of model %q", value, mInfo.Name)
+		}
+		mInfo.Variants[value] = variant
+	}
+	return variant, nil
+}
@@ -0,0 +1,142 @@
+package gen
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/alvaroloes/sdkgen/parser"
+	"github.com/alvaroloes/sdkgen/tests"
+	"github.com/kr/pretty"
+)
+
+type modelVariantsTestCase struct {
+	name             string
+	api              *parser.API
+	expectedError    error
+	expectedVariants map[string]map[string]string
+	expectedProps    map[string][]string
+}
+
+var modelVariantsTestCases = []modelVariantsTestCase{
+	{
+		name: "Variants in a property and in the response",
+		api: &parser.API{
+			Endpoints: []parser.Endpoint{
+				{
+					Method:    parser.GET,
+					URL:       tests.MustParseURL("https://www.alvarloes.com/feeds/:id"),
+					Resources: []parser.Resource{{Name: "feeds", Parameters: []string{"id"}}},
+					ResponseBody: map[string]interface{}{
+						"items: discriminator = type": []interface{}{
+							map[string]interface{}{"type": "photo", "id": json.Number("1"), "url": "http://alvarloes.com/1.png"},
+							map[string]interface{}{"type": "video", "id": json.Number("2"), "duration": json.Number("10")},
+							map[string]interface{}{"type": "photo", "id": json.Number("3"), "url": "http://alvarloes.com/3.png"},
+						},
+						"pinned: type = item": map[string]interface{}{"type": "link", "id": json.Number("4"), "href": "http://alvarloes.com"},
+					},
+				},
+				{
+					Method:       parser.GET,
+					URL:          tests.MustParseURL("https://www.alvarloes.com/notifications"),
+					Resources:    []parser.Resource{{Name: "notifications"}},
+					ResponseSpec: "discriminator = kind",
+					ResponseBody: []interface{}{
+						map[string]interface{}{"kind": "follow", "follower": "John"},
+					},
+				},
+			},
+		},
+		expectedVariants: map[string]map[string]string{
+			"item":         {"photo": "photoItem", "video": "videoItem", "link": "linkItem"},
+			"notification": {"follow": "followNotification"},
+		},
+		expectedProps: map[string][]string{
+			"feed":               {"items", "pinned"},
+			"item":               {"id", "type"},
+			"photoItem":          {"url"},
+			"videoItem":          {"duration"},
+			"linkItem":           {"href"},
+			"notification":       {"kind"},
+			"followNotification": {"follower"},
+		},
+	}, {
+		name: "Objects without discriminator belong to the base model",
+		api: &parser.API{
+			Endpoints: []parser.Endpoint{
+				{
+					Method:       parser.GET,
+					URL:          tests.MustParseURL("https://www.alvarloes.com/notifications"),
+					Resources:    []parser.Resource{{Name: "notifications"}},
+					ResponseSpec: "discriminator = kind",
+					ResponseBody: []interface{}{
+						map[string]interface{}{"kind": "follow", "id": json.Number("1"), "follower": "John"},
+						map[string]interface{}{"id": json.Number("2"), "kind": nil},
+					},
+				},
+			},
+		},
+		expectedVariants: map[string]map[string]string{
+			"notification": {"follow": "followNotification"},
+		},
+		expectedProps: map[string][]string{
+			"notification":       {"id", "kind"},
+			"followNotification": {"follower"},
+		},
+	}, {
+		name: "Several discriminators",
+		api: &parser.API{
+			Endpoints: []parser.Endpoint{
+				{
+					Method:       parser.GET,
+					URL:          tests.MustParseURL("https://www.alvarloes.com/notifications"),
+					Resources:    []parser.Resource{{Name: "notifications"}},
+					ResponseSpec: "discriminator = kind",
+					ResponseBody: []interface{}{map[string]interface{}{"kind": "follow"}},
+				},
+				{
+					Method:       parser.GET,
+					URL:          tests.MustParseURL("https://www.alvarloes.com/notifications/:id"),
+					Resources:    []parser.Resource{{Name: "notifications", Parameters: []string{"id"}}},
+					ResponseSpec: "discriminator = type",
+					ResponseBody: map[string]interface{}{"type": "follow"},
+				},
+			},
+		},
+		expectedError: ErrInvalidDiscriminator,
+	},
+}
+
+func TestModelVariants(t *testing.T) {
+	for _, testCase := range modelVariantsTestCases {
+		gen, ok := extractTestModelsInfo(t, testCase.name, testCase.api, Config{}, testCase.expectedError)
+		if !ok {
+			continue
+		}
+
+		variants := map[string]map[string]string{}
+		modelsProps := map[string][]string{}
+		for modelName, mInfo := range gen.modelsInfo {
+			if len(mInfo.Variants) > 0 {
+				variants[modelName] = map[string]string{}
+			}
+			for value, variant := range mInfo.Variants {
+				variants[modelName][value] = variant.Name
+				if variant.Parent != mInfo {
+					t.Errorf("Test %q: Expected variant %q to extend %q", testCase.name, variant.Name, modelName)
+				}
+			}
+			modelsProps[modelName] = []string{}
+			for propName := range mInfo.Properties {
+				modelsProps[modelName] = append(modelsProps[modelName], propName)
+			}
+			sort.Strings(modelsProps[modelName])
+		}
+		if diffs := pretty.Diff(testCase.expectedVariants, variants); len(diffs) > 0 {
+			t.Errorf("Test %q: Didn't get the expected variants. Differences are:\n%v", testCase.name, tests.FormattedDiff(diffs))
+		}
+		if diffs := pretty.Diff(testCase.expectedProps, modelsProps); len(diffs) > 0 {
+			t.Errorf("Test %q: Didn't get the expected properties. Differences are:\n%v", testCase.name, tests.FormattedDiff(diffs))
+		}
+	}
+}
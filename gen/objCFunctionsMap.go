@@ -1,6 +1,7 @@
 package gen
 
 import (
+	"strings"
 	"text/template"
 )
 
@@ -15,6 +16,34 @@ var invalidPropertyNames = map[string]struct{}{
 	"description": {},
 }
 
+// objCRequestFormatNames and objCResponseFormatNames must be kept in sync with the
+// enums declared in the ResourceManager
+var objCRequestFormatNames = map[RequestFormat]string{
+	JSONRequest:      "JSON",
+	FormRequest:      "Form",
+	MultipartRequest: "Multipart",
+}
+
+var objCResponseFormatNames = map[string]string{
+	contentJSON:   "JSON",
+	contentText:   "Text",
+	contentBool:   "Bool",
+	contentBinary: "Binary",
+}
+
+// objCPaginationNames must be kept in sync with the enum declared in the Paginator
+var objCPaginationNames = map[PaginationKind]string{
+	PagePagination:   "Page",
+	OffsetPagination: "Offset",
+	CursorPagination: "Cursor",
+}
+
+// objCContainerNames must be kept in sync with the names used in SerializableModelUtils
+var objCContainerNames = map[ContainerKind]string{
+	ArrayContainer: "array",
+	MapContainer:   "map",
+}
+
 var objCFuncMap = template.FuncMap{
 	"sanitizeVariable": func(varName string) string {
 		if _, invalid := invalidVarNames[varName]; invalid {
@@ -28,4 +57,49 @@ var objCFuncMap = template.FuncMap{
 		}
 		return propName
 	},
+	"isDate": func(prop property) bool {
+		return prop.DateFormat != ""
+	},
+	"unboxSelector": func(prop property) string {
+		if prop.IsArray || prop.IsMap {
+			return ""
+		}
+		return objCUnboxSelectorPerType[prop.Type]
+	},
+	"segmentParamString": func(param segmentParam, varName string) string {
+		switch param.Type {
+		case typeNSUUID:
+			return varName + ".UUIDString"
+		case typeNSString:
+			return varName
+		default:
+			return "@(" + varName + ").stringValue"
+		}
+	},
+	"containersLiteral": func(prop property) string {
+		literals := make([]string, 0, len(prop.Containers))
+		for _, container := range prop.Containers {
+			literals = append(literals, `@"`+objCContainerNames[container]+`"`)
+		}
+		return "@[" + strings.Join(literals, ", ") + "]"
+	},
+	"isFile": func(prop property) bool {
+		return prop.Type == typeNSURL && len(prop.Containers) == 0
+	},
+	"requestFormatName": func(epi endpointInfo) string {
+		return objCRequestFormatNames[epi.RequestFormat]
+	},
+	"responseFormatName": func(epi endpointInfo) string {
+		return objCResponseFormatNames[epi.responseFormat()]
+	},
+	"paginationName": func(p *pagination) string {
+		return objCPaginationNames[p.Kind]
+	},
+	"isNullableObject": func(prop property) bool {
+		if !prop.IsNullable && !prop.IsOptional {
+			return false
+		}
+		// Only object types can be annotated as nullable
+		return prop.Type == typeID || strings.HasSuffix(prop.TypeLabel, "*")
+	},
 }
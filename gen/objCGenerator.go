@@ -15,22 +15,44 @@ type objCTypeInfo struct {
 
 const (
 	typeBOOL         = "BOOL"
+	typeNSInteger    = "NSInteger"
+	typeInt64T       = "int64_t"
+	typeDouble       = "double"
 	typeNSNumber     = "NSNumber"
 	typeNSString     = "NSString"
+	typeNSDate       = "NSDate"
+	typeNSUUID       = "NSUUID"
+	typeNSURL        = "NSURL"
 	typeNSArray      = "NSArray"
 	typeNSDictionary = "NSDictionary"
+	typeID           = "id"
 )
 
 var objCTypePerGoType = map[string]objCTypeInfo{
-	"bool":    {Name: typeBOOL, Pointer: false},
-	"float64": {Name: typeNSNumber, Pointer: true},
-	"string":  {Name: typeNSString, Pointer: true},
+	"bool":      {Name: typeBOOL, Pointer: false},
+	typeInt:     {Name: typeNSInteger, Pointer: false},
+	typeInt64:   {Name: typeInt64T, Pointer: false},
+	typeFloat64: {Name: typeDouble, Pointer: false},
+	"string":    {Name: typeNSString, Pointer: true},
+	typeDate:    {Name: typeNSDate, Pointer: true},
+	typeFile:    {Name: typeNSURL, Pointer: true},
+}
+
+// objCUnboxSelectorPerType contains the NSNumber selectors needed to get the primitive values
+var objCUnboxSelectorPerType = map[string]string{
+	typeBOOL:      "boolValue",
+	typeNSInteger: "integerValue",
+	typeInt64T:    "longLongValue",
+	typeDouble:    "doubleValue",
 }
 
 type ObjCGen struct {
 }
 
-func (gen *ObjCGen) adaptModelsInfo(modelsInfo map[string]*modelInfo, api *parser.API, config Config) {
+func (gen *ObjCGen) adaptModelsInfo(modelsInfo map[string]*modelInfo, enumsInfo map[string]*enumInfo, api *parser.API, config Config) {
+	for _, enumInfo := range enumsInfo {
+		enumInfo.Name = config.APIPrefix + strings.Title(enumInfo.Name)
+	}
 	for _, modelInfo := range modelsInfo {
 		modelInfo.Name = config.APIPrefix + strings.Title(modelInfo.Name)
 		for propSpec, prop := range modelInfo.Properties {
@@ -38,6 +60,11 @@ func (gen *ObjCGen) adaptModelsInfo(modelsInfo map[string]*modelInfo, api *parse
 			modelInfo.Properties[propSpec] = prop
 			// TODO: Property attributes?
 		}
+		for _, epi := range modelInfo.EndpointsInfo {
+			for i, param := range epi.SegmentParams {
+				epi.SegmentParams[i].Type, epi.SegmentParams[i].TypeLabel = objCSegmentParamType(param)
+			}
+		}
 	}
 }
 
@@ -45,36 +72,67 @@ func (gen *ObjCGen) funcMap() template.FuncMap {
 	return objCFuncMap
 }
 
+func objCSegmentParamType(param segmentParam) (string, string) {
+	if param.Type == typeUUID {
+		return typeNSUUID, typeNSUUID + " *"
+	}
+	objCType := objCTypePerGoType[param.Type]
+	if objCType.Pointer {
+		return objCType.Name, objCType.Name + " *"
+	}
+	return objCType.Name, objCType.Name
+}
+
 func objCType(prop property, config Config) (string, string) {
 	var typeName, typeLabel string
+	var pointer bool
 
-	if prop.IsArray {
-		typeLabel = typeNSArray + "<"
-	} else if prop.IsMap {
-		typeLabel = typeNSDictionary + "<NSString *, "
+	if prop.IsRaw {
+		// Raw values are kept as they come in the JSON
+		switch {
+		case prop.IsArray:
+			return typeNSArray, typeNSArray + " *"
+		case prop.IsMap:
+			return typeNSDictionary, typeNSDictionary + " *"
+		default:
+			return typeID, typeID + " "
+		}
 	}
 
-	objCType, typeFound := objCTypePerGoType[prop.Type]
-	if typeFound {
-		typeName = objCType.Name
-		// In Objective C an array of booleans needs to be an array of NSNumbers
-		if (prop.IsArray || prop.IsMap) && typeName == typeBOOL {
-			typeLabel += typeNSNumber
-		} else {
-			typeLabel += typeName
+	if prop.hasUnknownType() {
+		// The type couldn't be inferred from the examples
+		typeName = typeID
+	} else if prop.Enum != nil {
+		// Enums are not boxed when missing, as they have an unknown value for that
+		typeName = prop.Enum.Name
+	} else if objCType, typeFound := objCTypePerGoType[prop.Type]; typeFound {
+		typeName, pointer = objCType.Name, objCType.Pointer
+		// In Objective C collections can only contain objects, and a primitive value
+		// that can be missing needs to be boxed in a NSNumber to represent the nil value
+		if !pointer && (len(prop.Containers) > 0 || prop.IsNullable || prop.IsOptional) {
+			typeName, pointer = typeNSNumber, true
 		}
 	} else {
-		typeName = config.APIPrefix + strings.Title(prop.Type)
-		typeLabel += typeName
+		// If type is not found, it means that the type is a class, so we need a pointer
+		typeName, pointer = config.APIPrefix+strings.Title(prop.Type), true
 	}
 
-	if prop.IsArray || prop.IsMap {
-		typeLabel += " *> *"
-	} else if !typeFound || objCType.Pointer {
-		// If type is not found, it means that the type is a class, so we need a pointer
+	if len(prop.Containers) == 0 && !pointer {
+		return typeName, typeName + " "
+	}
+
+	typeLabel = typeName
+	if pointer {
 		typeLabel += " *"
-	} else {
-		typeLabel += " "
+	}
+	// Wrap the element type from the innermost container to the outermost one
+	for i := len(prop.Containers) - 1; i >= 0; i-- {
+		switch prop.Containers[i] {
+		case ArrayContainer:
+			typeLabel = typeNSArray + "<" + typeLabel + "> *"
+		case MapContainer:
+			typeLabel = typeNSDictionary + "<NSString *, " + typeLabel + "> *"
+		}
 	}
 
 	return typeName, typeLabel
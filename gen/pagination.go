@@ -0,0 +1,108 @@
+package gen
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/alvaroloes/sdkgen/parser"
+	"github.com/juju/errors"
+)
+
+//go:generate enumer -type=PaginationKind
+
+// PaginationKind is how the pages of a list endpoint are requested
+type PaginationKind int
+
+const (
+	// PagePagination requests the page number, starting at 1
+	PagePagination PaginationKind = iota
+	// OffsetPagination requests the number of items to skip
+	OffsetPagination
+	// CursorPagination requests the cursor found in the previous page
+	CursorPagination
+)
+
+// Pagination kinds declared with the pagination attribute: `pagination = offset(start)`
+const (
+	paginationPage   = "page"
+	paginationOffset = "offset"
+	paginationCursor = "cursor"
+)
+
+var paginationKindPerName = map[string]PaginationKind{
+	paginationPage:   PagePagination,
+	paginationOffset: OffsetPagination,
+	paginationCursor: CursorPagination,
+}
+
+// defaultPageSizeParam is the query param with the number of items per page for page and offset paginations
+const defaultPageSizeParam = "limit"
+
+// pagination tells how to iterate the pages of an endpoint
+type pagination struct {
+	Kind PaginationKind
+	// Param is the query param with the page, offset or cursor
+	Param string
+	// PageSizeParam is the query param with the number of items per page, if any
+	PageSizeParam string
+	// ItemsKeyPath is where the items are in the response. They are the response itself if empty
+	ItemsKeyPath string
+	// NextKeyPath is where the cursor of the next page is in the response (only for cursor paginations)
+	NextKeyPath string
+}
+
+// newPagination returns the pagination declared in the endpoint attributes or nil if it's not paginated
+func newPagination(endpointAttrs endpointAttributes, method parser.HTTPMethod, responseKind ResponseKind) (*pagination, error) {
+	if endpointAttrs.pagination == "" {
+		return nil, nil
+	}
+	if method != parser.GET {
+		return nil, errors.Annotatef(ErrInvalidPagination, "only GET endpoints can be paginated")
+	}
+	if _, isNonJSON := responseFormatPerKind[responseKind]; isNonJSON {
+		return nil, errors.Annotatef(ErrInvalidPagination, "only JSON responses can be paginated")
+	}
+	kindName, param := endpointAttrs.pagination, ""
+	if match := segmentParamSpecRegexp.FindStringSubmatch(kindName); match != nil {
+		kindName, param = strings.TrimSpace(match[1]), strings.TrimSpace(match[2])
+	}
+	kind, found := paginationKindPerName[kindName]
+	if !found {
+		return nil, errors.Annotatef(ErrInvalidPagination, "%q is not a pagination. Use %q, %q or %q", kindName, paginationPage, paginationOffset, paginationCursor)
+	}
+	if param == "" {
+		param = kindName
+	}
+
+	p := &pagination{
+		Kind:          kind,
+		Param:         param,
+		PageSizeParam: endpointAttrs.pageSize,
+		ItemsKeyPath:  endpointAttrs.items,
+		NextKeyPath:   endpointAttrs.next,
+	}
+	switch kind {
+	case CursorPagination:
+		if p.NextKeyPath == "" {
+			return nil, errors.Annotatef(ErrInvalidPagination, "the %q attribute is needed to find the next cursor", attrKeyNext)
+		}
+	default:
+		if p.PageSizeParam == "" {
+			p.PageSizeParam = defaultPageSizeParam
+		}
+		// The items are needed to know when the last page is reached
+		if p.ItemsKeyPath == "" && responseKind != ArrayResponse && responseKind != RawArrayResponse {
+			return nil, errors.Annotatef(ErrInvalidPagination, "the response is not an array, so the %q attribute is needed to find the items", attrKeyItems)
+		}
+	}
+	return p, nil
+}
+
+// addQueryParams adds the pagination params not declared in the URL query
+func (p *pagination) addQueryParams(query url.Values) {
+	for _, param := range []string{p.Param, p.PageSizeParam} {
+		if _, found := query[param]; param != "" && !found {
+			query.Set(param, "")
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package gen
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/alvaroloes/sdkgen/parser"
+	"github.com/alvaroloes/sdkgen/tests"
+	"github.com/kr/pretty"
+)
+
+type paginationTestCase struct {
+	name               string
+	endpoint           parser.Endpoint
+	expectedPagination *pagination
+	expectedQuery      []string
+	expectedError      error
+}
+
+var paginationTestCases = []paginationTestCase{
+	{
+		name: "Not paginated",
+		endpoint: parser.Endpoint{
+			Method:       parser.GET,
+			URL:          tests.MustParseURL("https://www.alvarloes.com/posts"),
+			ResponseBody: []interface{}{map[string]interface{}{"id": "1"}},
+		},
+		expectedQuery: []string{},
+	}, {
+		name: "Page pagination with the default params",
+		endpoint: parser.Endpoint{
+			Method:       parser.GET,
+			URL:          tests.MustParseURL("https://www.alvarloes.com/posts"),
+			Spec:         "pagination = page",
+			ResponseBody: []interface{}{map[string]interface{}{"id": "1"}},
+		},
+		expectedPagination: &pagination{Kind: PagePagination, Param: "page", PageSizeParam: "limit"},
+		expectedQuery:      []string{"limit", "page"},
+	}, {
+		name: "Offset pagination with custom params and items",
+		endpoint: parser.Endpoint{
+			Method:       parser.GET,
+			URL:          tests.MustParseURL("https://www.alvarloes.com/posts?count=10&sort=date"),
+			Spec:         "pagination = offset(start); pageSize = count; items = data",
+			ResponseBody: map[string]interface{}{"data": []interface{}{map[string]interface{}{"id": "1"}}},
+		},
+		expectedPagination: &pagination{Kind: OffsetPagination, Param: "start", PageSizeParam: "count", ItemsKeyPath: "data"},
+		expectedQuery:      []string{"count", "sort", "start"},
+	}, {
+		name: "Cursor pagination",
+		endpoint: parser.Endpoint{
+			Method:       parser.GET,
+			URL:          tests.MustParseURL("https://www.alvarloes.com/posts"),
+			Spec:         "pagination = cursor(after); next = meta.next",
+			ResponseBody: map[string]interface{}{"meta": map[string]interface{}{"next": "abc"}},
+		},
+		expectedPagination: &pagination{Kind: CursorPagination, Param: "after", NextKeyPath: "meta.next"},
+		expectedQuery:      []string{"after"},
+	}, {
+		name: "Cursor pagination without the next cursor",
+		endpoint: parser.Endpoint{
+			Method: parser.GET,
+			URL:    tests.MustParseURL("https://www.alvarloes.com/posts"),
+			Spec:   "pagination = cursor",
+		},
+		expectedError: ErrInvalidPagination,
+	}, {
+		name: "Page pagination without items",
+		endpoint: parser.Endpoint{
+			Method:       parser.GET,
+			URL:          tests.MustParseURL("https://www.alvarloes.com/posts"),
+			Spec:         "pagination = page",
+			ResponseBody: map[string]interface{}{"data": []interface{}{}},
+		},
+		expectedError: ErrInvalidPagination,
+	}, {
+		name: "Not a GET endpoint",
+		endpoint: parser.Endpoint{
+			Method:       parser.POST,
+			URL:          tests.MustParseURL("https://www.alvarloes.com/posts"),
+			Spec:         "pagination = page",
+			ResponseBody: []interface{}{},
+		},
+		expectedError: ErrInvalidPagination,
+	}, {
+		name: "Unknown pagination",
+		endpoint: parser.Endpoint{
+			Method:       parser.GET,
+			URL:          tests.MustParseURL("https://www.alvarloes.com/posts"),
+			Spec:         "pagination = token",
+			ResponseBody: []interface{}{},
+		},
+		expectedError: ErrInvalidPagination,
+	},
+}
+
+func TestPagination(t *testing.T) {
+	for _, testCase := range paginationTestCases {
+		testCase.endpoint.Resources = []parser.Resource{{Name: "posts"}}
+		gen, ok := extractTestModelsInfo(t, testCase.name, &parser.API{Endpoints: []parser.Endpoint{testCase.endpoint}}, Config{}, testCase.expectedError)
+		if !ok {
+			continue
+		}
+
+		epi := gen.modelsInfo["post"].EndpointsInfo[0]
+		if diffs := pretty.Diff(testCase.expectedPagination, epi.Pagination); len(diffs) > 0 {
+			t.Errorf("Test %q: Didn't get the expected pagination. Differences are:\n%v", testCase.name, tests.FormattedDiff(diffs))
+		}
+		query := []string{}
+		for param := range epi.URLQueryParams {
+			query = append(query, param)
+		}
+		sort.Strings(query)
+		if diffs := pretty.Diff(testCase.expectedQuery, query); len(diffs) > 0 {
+			t.Errorf("Test %q: Didn't get the expected query params. Differences are:\n%v", testCase.name, tests.FormattedDiff(diffs))
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package gen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alvaroloes/sdkgen/parser"
+)
+
+const (
+	requestBodyName  = "request"
+	responseBodyName = "response"
+)
+
+// specLocation identifies where a value was found in the API spec
+type specLocation struct {
+	Method   parser.HTTPMethod
+	URLPath  string
+	Body     string
+	PropPath string
+
+	// origin is the model property that contains the object found here. It's empty for the bodies
+	origin string
+	// endpoint is the index of the endpoint in the API
+	endpoint int
+}
+
+func (l specLocation) String() string {
+	s := fmt.Sprintf("%s %s (%s body)", l.Method, l.URLPath, l.Body)
+	if l.PropPath != "" {
+		s += fmt.Sprintf(" at %q", l.PropPath)
+	}
+	return s
+}
+
+func (l specLocation) withProperty(propName string) specLocation {
+	if l.PropPath != "" {
+		propName = l.PropPath + "." + propName
+	}
+	l.PropPath = propName
+	return l
+}
+
+// insideOf returns the location of the objects contained in the property of the model
+func (l specLocation) insideOf(modelName, propName string) specLocation {
+	l.origin = modelName + "." + propName
+	return l
+}
+
+func (l specLocation) withIndex(index int) specLocation {
+	l.PropPath = fmt.Sprintf("%s[%d]", l.PropPath, index)
+	return l
+}
+
+// propertyTypeConflict represents the same property of a model being
+// found with different types in two places of the spec
+type propertyTypeConflict struct {
+	ModelName string
+	Existing  property
+	Found     property
+}
+
+func (c propertyTypeConflict) String() string {
+	return fmt.Sprintf("property %q of model %q is %s in %s but %s in %s",
+		c.Existing.Name, c.ModelName,
+		c.Existing.typeDescription(), c.Existing.location,
+		c.Found.typeDescription(), c.Found.location)
+}
+
+func conflictsString(conflicts []propertyTypeConflict) string {
+	descriptions := make([]string, 0, len(conflicts))
+	for _, c := range conflicts {
+		descriptions = append(descriptions, c.String())
+	}
+	return strings.Join(descriptions, "\n")
+}
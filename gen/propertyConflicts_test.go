@@ -0,0 +1,112 @@
+package gen
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/alvaroloes/sdkgen/parser"
+	"github.com/alvaroloes/sdkgen/tests"
+)
+
+type propertyConflictsTestCase struct {
+	name              string
+	api               *parser.API
+	strict            bool
+	expectedType      string
+	expectedConflicts int
+	expectedErr       bool
+}
+
+var conflictingAgeAPI = &parser.API{
+	Endpoints: []parser.Endpoint{
+		{
+			Method:       parser.GET,
+			URL:          tests.MustParseURL("https://www.alvarloes.com/people/:id"),
+			Resources:    []parser.Resource{{Name: "people", Parameters: []string{"id"}}},
+			ResponseBody: map[string]interface{}{"age": json.Number("20")},
+		}, {
+			Method:       parser.POST,
+			URL:          tests.MustParseURL("https://www.alvarloes.com/people"),
+			Resources:    []parser.Resource{{Name: "people"}},
+			RequestBody:  map[string]interface{}{"age": "20"},
+			ResponseBody: map[string]interface{}{"age": json.Number("20")},
+		},
+	},
+}
+
+var propertyConflictsTestCases = []propertyConflictsTestCase{
+	{
+		name:              "Conflicting types. First one wins",
+		api:               conflictingAgeAPI,
+		strict:            false,
+		expectedType:      "int",
+		expectedConflicts: 1,
+		expectedErr:       false,
+	}, {
+		name:              "Conflicting types. Strict mode",
+		api:               conflictingAgeAPI,
+		strict:            true,
+		expectedType:      "int",
+		expectedConflicts: 1,
+		expectedErr:       true,
+	}, {
+		name: "Empty array is completed by other examples",
+		api: &parser.API{
+			Endpoints: []parser.Endpoint{
+				{
+					Method:    parser.GET,
+					URL:       tests.MustParseURL("https://www.alvarloes.com/people"),
+					Resources: []parser.Resource{{Name: "people"}},
+					ResponseBody: []interface{}{
+						map[string]interface{}{"age": []interface{}{}},
+						map[string]interface{}{"age": []interface{}{json.Number("20")}},
+					},
+				},
+			},
+		},
+		strict:            true,
+		expectedType:      "int",
+		expectedConflicts: 0,
+		expectedErr:       false,
+	}, {
+		name: "Type declared in a later example",
+		api: &parser.API{
+			Endpoints: []parser.Endpoint{
+				{
+					Method:    parser.GET,
+					URL:       tests.MustParseURL("https://www.alvarloes.com/people"),
+					Resources: []parser.Resource{{Name: "people"}},
+					ResponseBody: []interface{}{
+						map[string]interface{}{"age": nil},
+						map[string]interface{}{"age": json.Number("20")},
+						map[string]interface{}{"age: type = string": "20"},
+					},
+				},
+			},
+		},
+		strict:            true,
+		expectedType:      "string",
+		expectedConflicts: 0,
+		expectedErr:       false,
+	},
+}
+
+func TestPropertyTypeConflicts(t *testing.T) {
+	for _, testCase := range propertyConflictsTestCases {
+		gen := Generator{
+			api:    testCase.api,
+			config: Config{StrictPropertyTypes: testCase.strict},
+		}
+		err := gen.extractModelsInfo()
+
+		if (err != nil) != testCase.expectedErr {
+			t.Errorf("Test %q: Expected error: %v, got: %v", testCase.name, testCase.expectedErr, err)
+		}
+		if len(gen.propertyConflicts) != testCase.expectedConflicts {
+			t.Errorf("Test %q: Expected %d conflicts, got: %v", testCase.name, testCase.expectedConflicts, gen.propertyConflicts)
+		}
+		if propType := gen.modelsInfo["person"].Properties["age"].Type; propType != testCase.expectedType {
+			t.Errorf("Test %q: Expected type %q, got: %q", testCase.name, testCase.expectedType, propType)
+		}
+	}
+}
@@ -4,9 +4,9 @@ package gen
 
 import "fmt"
 
-const _ResponseKind_name = "RawResponseModelResponseMapResponseRawMapResponseArrayResponseRawArrayResponseEmptyResponse"
+const _ResponseKind_name = "RawResponseModelResponseMapResponseRawMapResponseArrayResponseRawArrayResponseEmptyResponseTextResponseBoolResponseBinaryResponse"
 
-var _ResponseKind_index = [...]uint8{0, 11, 24, 35, 49, 62, 78, 91}
+var _ResponseKind_index = [...]uint8{0, 11, 24, 35, 49, 62, 78, 91, 103, 115, 129}
 
 func (i ResponseKind) String() string {
 	if i < 0 || i >= ResponseKind(len(_ResponseKind_index)-1) {
@@ -16,13 +16,16 @@ func (i ResponseKind) String() string {
 }
 
 var _ResponseKindNameToValue_map = map[string]ResponseKind{
-	_ResponseKind_name[0:11]:  0,
-	_ResponseKind_name[11:24]: 1,
-	_ResponseKind_name[24:35]: 2,
-	_ResponseKind_name[35:49]: 3,
-	_ResponseKind_name[49:62]: 4,
-	_ResponseKind_name[62:78]: 5,
-	_ResponseKind_name[78:91]: 6,
+	_ResponseKind_name[0:11]:    0,
+	_ResponseKind_name[11:24]:   1,
+	_ResponseKind_name[24:35]:   2,
+	_ResponseKind_name[35:49]:   3,
+	_ResponseKind_name[49:62]:   4,
+	_ResponseKind_name[62:78]:   5,
+	_ResponseKind_name[78:91]:   6,
+	_ResponseKind_name[91:103]:  7,
+	_ResponseKind_name[103:115]: 8,
+	_ResponseKind_name[115:129]: 9,
 }
 
 func ResponseKindString(s string) (ResponseKind, error) {
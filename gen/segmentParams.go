@@ -0,0 +1,89 @@
+package gen
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/alvaroloes/sdkgen/parser"
+	"github.com/jinzhu/inflection"
+	"github.com/juju/errors"
+)
+
+// typeUUID is the type of the segment parameters declared as UUIDs
+const typeUUID = "uuid"
+
+const segmentParamPrefix = ":"
+
+// segmentParamTypes are the types that can be declared for a segment parameter, like in "/posts/:id(int)"
+var segmentParamTypes = map[string]struct{}{
+	"string":  {},
+	typeInt:   {},
+	typeInt64: {},
+	typeUUID:  {},
+}
+
+var segmentParamSpecRegexp = regexp.MustCompile(`^([^()]+)\((.*)\)$`)
+
+type segmentParam struct {
+	// Name is unique among the segment parameters of the endpoint
+	Name      string
+	Type      string
+	TypeLabel string
+}
+
+// parseSegmentParam extracts the name and the type of a segment parameter specification
+func parseSegmentParam(spec string) (name, paramType string, err error) {
+	match := segmentParamSpecRegexp.FindStringSubmatch(spec)
+	if match == nil {
+		return spec, "string", nil
+	}
+	name, paramType = match[1], strings.TrimSpace(match[2])
+	if _, valid := segmentParamTypes[paramType]; !valid {
+		return "", "", errors.Annotatef(ErrInvalidSegmentParam, "type %q of parameter %q", paramType, name)
+	}
+	return name, paramType, nil
+}
+
+// extractSegmentParamsRenamingDups returns the segment parameters of the resources. The parameters whose
+// name is repeated are prefixed with the name of its resource: "/posts/:id/comments/:id" has "postId" and "commentId"
+func extractSegmentParamsRenamingDups(resources []parser.Resource) ([]segmentParam, error) {
+	segmentParams := []segmentParam{}
+	resourceNames := []string{}
+	countPerName := map[string]int{}
+	for _, r := range resources {
+		for _, spec := range r.Parameters {
+			name, paramType, err := parseSegmentParam(spec)
+			if err != nil {
+				return nil, err
+			}
+			segmentParams = append(segmentParams, segmentParam{
+				Name:      name,
+				Type:      paramType,
+				TypeLabel: paramType,
+			})
+			resourceNames = append(resourceNames, r.Name)
+			countPerName[name]++
+		}
+	}
+
+	for i, param := range segmentParams {
+		if countPerName[param.Name] > 1 {
+			segmentParams[i].Name = camelCase(inflection.Singular(resourceNames[i]) + "_" + param.Name)
+		}
+	}
+	return segmentParams, nil
+}
+
+// urlPathWithSegmentParams returns the path of the URL with the segment parameters renamed and without their types
+func urlPathWithSegmentParams(url *url.URL, segmentParams []segmentParam) string {
+	segments := strings.Split(url.Path, "/")
+	paramIndex := 0
+	for i, segment := range segments {
+		if strings.HasPrefix(strings.TrimSpace(segment), segmentParamPrefix) && paramIndex < len(segmentParams) {
+			segments[i] = segmentParamPrefix + segmentParams[paramIndex].Name
+			paramIndex++
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,68 @@
+package gen
+
+import (
+	"testing"
+
+	"github.com/alvaroloes/sdkgen/parser"
+	"github.com/alvaroloes/sdkgen/tests"
+	"github.com/juju/errors"
+	"github.com/kr/pretty"
+)
+
+type segmentParamsTestCase struct {
+	name            string
+	url             string
+	resources       []parser.Resource
+	expectedParams  []segmentParam
+	expectedURLPath string
+	expectedError   bool
+}
+
+var segmentParamsTestCases = []segmentParamsTestCase{
+	{
+		name:      "Duplicated names",
+		url:       "https://www.alvarloes.com/posts/:id/comments/:id",
+		resources: []parser.Resource{{Name: "posts", Parameters: []string{"id"}}, {Name: "comments", Parameters: []string{"id"}}},
+		expectedParams: []segmentParam{
+			{Name: "postId", Type: "string", TypeLabel: "string"},
+			{Name: "commentId", Type: "string", TypeLabel: "string"},
+		},
+		expectedURLPath: "/posts/:postId/comments/:commentId",
+	}, {
+		name:      "Unique names and types",
+		url:       "https://www.alvarloes.com/people/:personId(int64)/posts/:id(uuid)",
+		resources: []parser.Resource{{Name: "people", Parameters: []string{"personId(int64)"}}, {Name: "posts", Parameters: []string{"id(uuid)"}}},
+		expectedParams: []segmentParam{
+			{Name: "personId", Type: "int64", TypeLabel: "int64"},
+			{Name: "id", Type: "uuid", TypeLabel: "uuid"},
+		},
+		expectedURLPath: "/people/:personId/posts/:id",
+	}, {
+		name:          "Invalid type",
+		url:           "https://www.alvarloes.com/posts/:id(number)",
+		resources:     []parser.Resource{{Name: "posts", Parameters: []string{"id(number)"}}},
+		expectedError: true,
+	},
+}
+
+func TestSegmentParams(t *testing.T) {
+	for _, testCase := range segmentParamsTestCases {
+		params, err := extractSegmentParamsRenamingDups(testCase.resources)
+		if testCase.expectedError {
+			if errors.Cause(err) != ErrInvalidSegmentParam {
+				t.Errorf("Test %q: Expected error %q, got: %v", testCase.name, ErrInvalidSegmentParam, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test %q: Unexpected error: %v", testCase.name, err)
+			continue
+		}
+		if diffs := pretty.Diff(testCase.expectedParams, params); len(diffs) > 0 {
+			t.Errorf("Test %q: Didn't get the expected segment params. Differences are:\n%v", testCase.name, tests.FormattedDiff(diffs))
+		}
+		if urlPath := urlPathWithSegmentParams(tests.MustParseURL(testCase.url), params); urlPath != testCase.expectedURLPath {
+			t.Errorf("Test %q: Expected URL path %q, got: %q", testCase.name, testCase.expectedURLPath, urlPath)
+		}
+	}
+}
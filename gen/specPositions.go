@@ -0,0 +1,97 @@
+package gen
+
+import "strings"
+
+// SpecPosition is where a value is found in the API spec, so that editors can point at it
+type SpecPosition struct {
+	// Endpoint is the index of the endpoint in the API
+	Endpoint int
+	// Body is "request" or "response". It is empty for the endpoint line
+	Body string
+	// Path is where the value is inside the body, like "comments[0].author".
+	// It is empty for the body itself
+	Path string
+}
+
+// Bodies of the spec positions
+const (
+	RequestBody  = requestBodyName
+	ResponseBody = responseBodyName
+)
+
+// PropertyDescription describes a property of a model extracted from the spec
+type PropertyDescription struct {
+	ModelName    string
+	PropertyName string
+	// Type describes the type of the values, like "array of comment"
+	Type       string
+	IsOptional bool
+	IsNullable bool
+}
+
+// propertyRef is a property of a model. It is looked up by name as the property can be moved
+// to a parent model when resolving the inheritance
+type propertyRef struct {
+	model *modelInfo
+	name  string
+}
+
+// specPosition returns the position of the location. The locations of the enveloped responses
+// are relative to the payload, while the positions are relative to the whole body
+func (g *Generator) specPosition(location specLocation) SpecPosition {
+	path := location.PropPath
+	if env := g.responseEnvelopes[location.endpoint]; env != nil && location.Body == responseBodyName {
+		switch {
+		case path == "":
+			path = env.DataKey
+		case strings.HasPrefix(path, "["):
+			path = env.DataKey + path
+		default:
+			path = env.DataKey + "." + path
+		}
+	}
+	return SpecPosition{
+		Endpoint: location.endpoint,
+		Body:     location.Body,
+		Path:     path,
+	}
+}
+
+// setModelPosition sets where the model is defined if it's the first time an example of it is found
+func (g *Generator) setModelPosition(mInfo *modelInfo, location specLocation) {
+	if _, found := g.modelPositions[mInfo]; !found {
+		g.modelPositions[mInfo] = g.specPosition(location)
+	}
+}
+
+// PropertyAt returns the property whose key is at the position of the spec.
+// The models must be extracted before with Lint
+func (g *Generator) PropertyAt(position SpecPosition) (PropertyDescription, bool) {
+	ref, found := g.propertyPositions[position]
+	if !found {
+		return PropertyDescription{}, false
+	}
+	for mInfo := ref.model; mInfo != nil; mInfo = mInfo.Parent {
+		if prop, found := mInfo.Properties[ref.name]; found {
+			return PropertyDescription{
+				ModelName:    mInfo.Name,
+				PropertyName: prop.NameLabel,
+				Type:         prop.typeDescription(),
+				IsOptional:   prop.IsOptional,
+				IsNullable:   prop.IsNullable,
+			}, true
+		}
+	}
+	return PropertyDescription{}, false
+}
+
+// ModelPosition returns where the first example of the model declared with the type name is
+// found in the spec. The models must be extracted before with Lint
+func (g *Generator) ModelPosition(typeName string) (SpecPosition, bool) {
+	mInfo, found := g.modelsInfo[g.modelName(typeName)]
+	if !found {
+		return SpecPosition{}, false
+	}
+	position, found := g.modelPositions[mInfo]
+	return position, found
+}
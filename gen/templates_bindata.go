@@ -2,6 +2,10 @@
 // sources:
 // ../templates/objc/--APIName--.h.tpl
 // ../templates/objc/--APIName--.m.tpl
+// ../templates/objc/--APIPrefix--Enums.h.tpl
+// ../templates/objc/--APIPrefix--Enums.m.tpl
+// ../templates/objc/--APIPrefix--Paginator.h.tpl
+// ../templates/objc/--APIPrefix--Paginator.m.tpl
 // ../templates/objc/--APIPrefix--ResourceManager.h.tpl
 // ../templates/objc/--APIPrefix--ResourceManager.m.tpl
 // ../templates/objc/--APIPrefix--SerializableModelProtocol.h.tpl
@@ -10,8 +14,11 @@
 // ../templates/objc/--APIPrefix--ServiceProtocol.h.tpl
 // ../templates/objc/--APIPrefix--URLHelper.h.tpl
 // ../templates/objc/--APIPrefix--URLHelper.m.tpl
+// ../templates/objc/common/extensionHeaderComment.tpl
 // ../templates/objc/common/preHeaderComment.tpl
 // ../templates/objc/common/services.tpl
+// ../templates/objc/extension/--ModelName--+Custom.h.tpl
+// ../templates/objc/extension/--ModelName--+Custom.m.tpl
 // ../templates/objc/model/--ModelName--.h.tpl
 // ../templates/objc/model/--ModelName--.m.tpl
 // ../templates/objc/service/--ModelName--Service.h.tpl
@@ -78,6 +85,78 @@ func TemplatesObjcApinameMTpl() (*asset, error) {
 	return a, err
 }
 
+// TemplatesObjcApiprefixEnumsHTpl reads file data from disk. It returns an error on failure.
+func TemplatesObjcApiprefixEnumsHTpl() (*asset, error) {
+	path := "/Users/alvaro/Projects/go/src/github.com/alvaroloes/sdkgen/templates/objc/--APIPrefix--Enums.h.tpl"
+	name := "../templates/objc/--APIPrefix--Enums.h.tpl"
+	bytes, err := bindataRead(path, name)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		err = fmt.Errorf("Error reading asset info %s at %s: %v", name, path, err)
+	}
+
+	a := &asset{bytes: bytes, info: fi}
+	return a, err
+}
+
+// TemplatesObjcApiprefixEnumsMTpl reads file data from disk. It returns an error on failure.
+func TemplatesObjcApiprefixEnumsMTpl() (*asset, error) {
+	path := "/Users/alvaro/Projects/go/src/github.com/alvaroloes/sdkgen/templates/objc/--APIPrefix--Enums.m.tpl"
+	name := "../templates/objc/--APIPrefix--Enums.m.tpl"
+	bytes, err := bindataRead(path, name)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		err = fmt.Errorf("Error reading asset info %s at %s: %v", name, path, err)
+	}
+
+	a := &asset{bytes: bytes, info: fi}
+	return a, err
+}
+
+// TemplatesObjcApiprefixPaginatorHTpl reads file data from disk. It returns an error on failure.
+func TemplatesObjcApiprefixPaginatorHTpl() (*asset, error) {
+	path := "/Users/alvaro/Projects/go/src/github.com/alvaroloes/sdkgen/templates/objc/--APIPrefix--Paginator.h.tpl"
+	name := "../templates/objc/--APIPrefix--Paginator.h.tpl"
+	bytes, err := bindataRead(path, name)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		err = fmt.Errorf("Error reading asset info %s at %s: %v", name, path, err)
+	}
+
+	a := &asset{bytes: bytes, info: fi}
+	return a, err
+}
+
+// TemplatesObjcApiprefixPaginatorMTpl reads file data from disk. It returns an error on failure.
+func TemplatesObjcApiprefixPaginatorMTpl() (*asset, error) {
+	path := "/Users/alvaro/Projects/go/src/github.com/alvaroloes/sdkgen/templates/objc/--APIPrefix--Paginator.m.tpl"
+	name := "../templates/objc/--APIPrefix--Paginator.m.tpl"
+	bytes, err := bindataRead(path, name)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		err = fmt.Errorf("Error reading asset info %s at %s: %v", name, path, err)
+	}
+
+	a := &asset{bytes: bytes, info: fi}
+	return a, err
+}
+
 // TemplatesObjcApiprefixResourcemanagerHTpl reads file data from disk. It returns an error on failure.
 func TemplatesObjcApiprefixResourcemanagerHTpl() (*asset, error) {
 	path := "/Users/alvaro/Projects/go/src/github.com/alvaroloes/sdkgen/templates/objc/--APIPrefix--ResourceManager.h.tpl"
@@ -222,6 +301,24 @@ func TemplatesObjcApiprefixUrlhelperMTpl() (*asset, error) {
 	return a, err
 }
 
+// TemplatesObjcCommonExtensionheadercommentTpl reads file data from disk. It returns an error on failure.
+func TemplatesObjcCommonExtensionheadercommentTpl() (*asset, error) {
+	path := "/Users/alvaro/Projects/go/src/github.com/alvaroloes/sdkgen/templates/objc/common/extensionHeaderComment.tpl"
+	name := "../templates/objc/common/extensionHeaderComment.tpl"
+	bytes, err := bindataRead(path, name)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		err = fmt.Errorf("Error reading asset info %s at %s: %v", name, path, err)
+	}
+
+	a := &asset{bytes: bytes, info: fi}
+	return a, err
+}
+
 // TemplatesObjcCommonPreheadercommentTpl reads file data from disk. It returns an error on failure.
 func TemplatesObjcCommonPreheadercommentTpl() (*asset, error) {
 	path := "/Users/alvaro/Projects/go/src/github.com/alvaroloes/sdkgen/templates/objc/common/preHeaderComment.tpl"
@@ -258,6 +355,42 @@ func TemplatesObjcCommonServicesTpl() (*asset, error) {
 	return a, err
 }
 
+// TemplatesObjcExtensionModelnameCustomHTpl reads file data from disk. It returns an error on failure.
+func TemplatesObjcExtensionModelnameCustomHTpl() (*asset, error) {
+	path := "/Users/alvaro/Projects/go/src/github.com/alvaroloes/sdkgen/templates/objc/extension/--ModelName--+Custom.h.tpl"
+	name := "../templates/objc/extension/--ModelName--+Custom.h.tpl"
+	bytes, err := bindataRead(path, name)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		err = fmt.Errorf("Error reading asset info %s at %s: %v", name, path, err)
+	}
+
+	a := &asset{bytes: bytes, info: fi}
+	return a, err
+}
+
+// TemplatesObjcExtensionModelnameCustomMTpl reads file data from disk. It returns an error on failure.
+func TemplatesObjcExtensionModelnameCustomMTpl() (*asset, error) {
+	path := "/Users/alvaro/Projects/go/src/github.com/alvaroloes/sdkgen/templates/objc/extension/--ModelName--+Custom.m.tpl"
+	name := "../templates/objc/extension/--ModelName--+Custom.m.tpl"
+	bytes, err := bindataRead(path, name)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		err = fmt.Errorf("Error reading asset info %s at %s: %v", name, path, err)
+	}
+
+	a := &asset{bytes: bytes, info: fi}
+	return a, err
+}
+
 // TemplatesObjcModelModelnameHTpl reads file data from disk. It returns an error on failure.
 func TemplatesObjcModelModelnameHTpl() (*asset, error) {
 	path := "/Users/alvaro/Projects/go/src/github.com/alvaroloes/sdkgen/templates/objc/model/--ModelName--.h.tpl"
@@ -384,6 +517,10 @@ func AssetNames() []string {
 var _bindata = map[string]func() (*asset, error){
 	"../templates/objc/--APIName--.h.tpl":                            TemplatesObjcApinameHTpl,
 	"../templates/objc/--APIName--.m.tpl":                            TemplatesObjcApinameMTpl,
+	"../templates/objc/--APIPrefix--Enums.h.tpl":                     TemplatesObjcApiprefixEnumsHTpl,
+	"../templates/objc/--APIPrefix--Enums.m.tpl":                     TemplatesObjcApiprefixEnumsMTpl,
+	"../templates/objc/--APIPrefix--Paginator.h.tpl":                 TemplatesObjcApiprefixPaginatorHTpl,
+	"../templates/objc/--APIPrefix--Paginator.m.tpl":                 TemplatesObjcApiprefixPaginatorMTpl,
 	"../templates/objc/--APIPrefix--ResourceManager.h.tpl":           TemplatesObjcApiprefixResourcemanagerHTpl,
 	"../templates/objc/--APIPrefix--ResourceManager.m.tpl":           TemplatesObjcApiprefixResourcemanagerMTpl,
 	"../templates/objc/--APIPrefix--SerializableModelProtocol.h.tpl": TemplatesObjcApiprefixSerializablemodelprotocolHTpl,
@@ -392,8 +529,11 @@ var _bindata = map[string]func() (*asset, error){
 	"../templates/objc/--APIPrefix--ServiceProtocol.h.tpl":           TemplatesObjcApiprefixServiceprotocolHTpl,
 	"../templates/objc/--APIPrefix--URLHelper.h.tpl":                 TemplatesObjcApiprefixUrlhelperHTpl,
 	"../templates/objc/--APIPrefix--URLHelper.m.tpl":                 TemplatesObjcApiprefixUrlhelperMTpl,
+	"../templates/objc/common/extensionHeaderComment.tpl":            TemplatesObjcCommonExtensionheadercommentTpl,
 	"../templates/objc/common/preHeaderComment.tpl":                  TemplatesObjcCommonPreheadercommentTpl,
 	"../templates/objc/common/services.tpl":                          TemplatesObjcCommonServicesTpl,
+	"../templates/objc/extension/--ModelName--+Custom.h.tpl":         TemplatesObjcExtensionModelnameCustomHTpl,
+	"../templates/objc/extension/--ModelName--+Custom.m.tpl":         TemplatesObjcExtensionModelnameCustomMTpl,
 	"../templates/objc/model/--ModelName--.h.tpl":                    TemplatesObjcModelModelnameHTpl,
 	"../templates/objc/model/--ModelName--.m.tpl":                    TemplatesObjcModelModelnameMTpl,
 	"../templates/objc/service/--ModelName--Service.h.tpl":           TemplatesObjcServiceModelnameServiceHTpl,
@@ -446,6 +586,10 @@ var _bintree = &bintree{nil, map[string]*bintree{
 			"objc": &bintree{nil, map[string]*bintree{
 				"--APIName--.h.tpl":                            &bintree{TemplatesObjcApinameHTpl, map[string]*bintree{}},
 				"--APIName--.m.tpl":                            &bintree{TemplatesObjcApinameMTpl, map[string]*bintree{}},
+				"--APIPrefix--Enums.h.tpl":                     &bintree{TemplatesObjcApiprefixEnumsHTpl, map[string]*bintree{}},
+				"--APIPrefix--Enums.m.tpl":                     &bintree{TemplatesObjcApiprefixEnumsMTpl, map[string]*bintree{}},
+				"--APIPrefix--Paginator.h.tpl":                 &bintree{TemplatesObjcApiprefixPaginatorHTpl, map[string]*bintree{}},
+				"--APIPrefix--Paginator.m.tpl":                 &bintree{TemplatesObjcApiprefixPaginatorMTpl, map[string]*bintree{}},
 				"--APIPrefix--ResourceManager.h.tpl":           &bintree{TemplatesObjcApiprefixResourcemanagerHTpl, map[string]*bintree{}},
 				"--APIPrefix--ResourceManager.m.tpl":           &bintree{TemplatesObjcApiprefixResourcemanagerMTpl, map[string]*bintree{}},
 				"--APIPrefix--SerializableModelProtocol.h.tpl": &bintree{TemplatesObjcApiprefixSerializablemodelprotocolHTpl, map[string]*bintree{}},
@@ -455,8 +599,13 @@ var _bintree = &bintree{nil, map[string]*bintree{
 				"--APIPrefix--URLHelper.h.tpl":                 &bintree{TemplatesObjcApiprefixUrlhelperHTpl, map[string]*bintree{}},
 				"--APIPrefix--URLHelper.m.tpl":                 &bintree{TemplatesObjcApiprefixUrlhelperMTpl, map[string]*bintree{}},
 				"common": &bintree{nil, map[string]*bintree{
-					"preHeaderComment.tpl": &bintree{TemplatesObjcCommonPreheadercommentTpl, map[string]*bintree{}},
-					"services.tpl":         &bintree{TemplatesObjcCommonServicesTpl, map[string]*bintree{}},
+					"extensionHeaderComment.tpl": &bintree{TemplatesObjcCommonExtensionheadercommentTpl, map[string]*bintree{}},
+					"preHeaderComment.tpl":       &bintree{TemplatesObjcCommonPreheadercommentTpl, map[string]*bintree{}},
+					"services.tpl":               &bintree{TemplatesObjcCommonServicesTpl, map[string]*bintree{}},
+				}},
+				"extension": &bintree{nil, map[string]*bintree{
+					"--ModelName--+Custom.h.tpl": &bintree{TemplatesObjcExtensionModelnameCustomHTpl, map[string]*bintree{}},
+					"--ModelName--+Custom.m.tpl": &bintree{TemplatesObjcExtensionModelnameCustomMTpl, map[string]*bintree{}},
 				}},
 				"model": &bintree{nil, map[string]*bintree{
 					"--ModelName--.h.tpl": &bintree{TemplatesObjcModelModelnameHTpl, map[string]*bintree{}},
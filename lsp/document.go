@@ -0,0 +1,443 @@
+package lsp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/alvaroloes/sdkgen/gen"
+	"github.com/alvaroloes/sdkgen/parser"
+	"github.com/juju/errors"
+)
+
+const diagnosticSource = "sdkgen"
+
+// analysisFailureMessage starts the diagnostic reported when the analysis fails unexpectedly
+const analysisFailureMessage = "the spec can't be analyzed"
+
+// Attributes whose value is a model name, to go to its definition
+const (
+	typeAttributeKey    = "type"
+	extendsAttributeKey = "extends"
+)
+
+// Separators of the attributes in the specifications
+const (
+	propertySpecSeparator = ":"
+	attrSeparator         = ";"
+	attrKeyValueSeparator = "="
+)
+
+// endpointPrefixRegexp matches the start of an endpoint line up to the specification after the URL
+var endpointPrefixRegexp = regexp.MustCompile(`^\s*(AUTH_TOKEN\s+)?(GET|POST|PUT|DELETE)\s+\S+\s`)
+
+// document is an open spec. It is analyzed when opened and on each change
+type document struct {
+	uri  string
+	text []byte
+	// lineStarts are the offsets where each line starts
+	lineStarts []int
+	outlines   []parser.EndpointOutline
+	values     []jsonValue
+	// generator has the models extracted from the spec. It is nil if the spec is not valid
+	generator   *gen.Generator
+	diagnostics []Diagnostic
+}
+
+// jsonValue is a value found in a JSON body of the spec
+type jsonValue struct {
+	position gen.SpecPosition
+	// key is the range of the key of an object property, quotes included.
+	// It is empty for the elements of arrays and the bodies
+	key   parser.Range
+	value parser.Range
+}
+
+// body is a body of an endpoint along with its name
+type body struct {
+	name string
+	*parser.BodyOutline
+}
+
+func newDocument(uri string, text []byte, config gen.Config) *document {
+	doc := &document{
+		uri:        uri,
+		text:       text,
+		lineStarts: []int{0},
+		outlines:   parser.Outline(text),
+	}
+	for i, b := range text {
+		if b == '\n' {
+			doc.lineStarts = append(doc.lineStarts, i+1)
+		}
+	}
+	for i, outline := range doc.outlines {
+		for _, body := range bodies(outline) {
+			if body.JSON.End > body.JSON.Start {
+				scanner := jsonScanner{
+					text:     text[:body.JSON.End],
+					position: gen.SpecPosition{Endpoint: i, Body: body.name},
+				}
+				scanner.scanValue(body.JSON.Start, "", parser.Range{})
+				doc.values = append(doc.values, scanner.values...)
+			}
+		}
+	}
+	doc.analyze(config)
+	return doc
+}
+
+func bodies(outline parser.EndpointOutline) []body {
+	var endpointBodies []body
+	if outline.Request != nil {
+		endpointBodies = append(endpointBodies, body{gen.RequestBody, outline.Request})
+	}
+	if outline.Response != nil {
+		endpointBodies = append(endpointBodies, body{gen.ResponseBody, outline.Response})
+	}
+	return endpointBodies
+}
+
+// analyze parses the spec and extracts its models, reporting the errors and the problems found
+func (doc *document) analyze(config gen.Config) {
+	// The spec is analyzed while being typed, so an unexpected failure must not stop the server
+	defer func() {
+		if r := recover(); r != nil {
+			doc.generator = nil
+			doc.addDiagnostic(parser.Range{Start: 0, End: doc.lineEnd(0)}, errorSeverity, "", fmt.Sprintf("%s: %v", analysisFailureMessage, r))
+		}
+	}()
+
+	// Each endpoint is parsed on its own to know where the errors are
+	valid := true
+	for _, outline := range doc.outlines {
+		if _, err := parser.NewAPI(doc.text[outline.Line.Start:outline.End]); err != nil {
+			valid = false
+			doc.addDiagnostic(doc.parseErrorRange(outline, err), errorSeverity, "", err.Error())
+		}
+	}
+	if !valid {
+		return
+	}
+
+	api, err := parser.NewAPI(doc.text)
+	if err != nil {
+		doc.addDiagnostic(doc.errorRange(err), errorSeverity, "", err.Error())
+		return
+	}
+	generator, err := gen.New(gen.ObjC, api, config)
+	if err != nil {
+		doc.addDiagnostic(doc.errorRange(err), errorSeverity, "", err.Error())
+		return
+	}
+	issues, err := generator.Lint()
+	if err != nil {
+		doc.addDiagnostic(doc.errorRange(err), errorSeverity, "", err.Error())
+		return
+	}
+	doc.generator = &generator
+	for _, issue := range issues {
+		issueRange := doc.errorRange(errors.New(issue.Location))
+		if issue.Position != nil {
+			issueRange = doc.specPositionRange(*issue.Position)
+		}
+		doc.addDiagnostic(issueRange, warningSeverity, issue.Rule, issue.Message)
+	}
+}
+
+func (doc *document) addDiagnostic(r parser.Range, severity int, code, message string) {
+	doc.diagnostics = append(doc.diagnostics, Diagnostic{
+		Range:    doc.lspRange(r),
+		Severity: severity,
+		Code:     code,
+		Source:   diagnosticSource,
+		Message:  message,
+	})
+}
+
+// parseErrorRange returns the range of the error found parsing the endpoint: the invalid character
+// of its JSON bodies or the endpoint line for the rest of errors
+func (doc *document) parseErrorRange(outline parser.EndpointOutline, err error) parser.Range {
+	errorBody := outline.Response
+	if strings.Contains(err.Error(), "JSON request body") {
+		errorBody = outline.Request
+	}
+	if errorBody == nil {
+		return outline.Line
+	}
+	switch cause := errors.Cause(err).(type) {
+	case *json.SyntaxError:
+		// The offset is the number of bytes read when the error is found
+		offset := errorBody.JSON.Start + int(cause.Offset) - 1
+		if offset >= errorBody.JSON.End {
+			offset = errorBody.JSON.End - 1
+		}
+		return parser.Range{Start: offset, End: offset + 1}
+	default:
+		if cause == io.ErrUnexpectedEOF || cause == io.EOF || cause == parser.ErrUnclosedJSON {
+			return parser.Range{Start: errorBody.JSON.End - 1, End: errorBody.JSON.End}
+		}
+	}
+	return outline.Line
+}
+
+// errorRange returns the line of the endpoint whose method and path are mentioned in the error,
+// or the first line if there is none
+func (doc *document) errorRange(err error) parser.Range {
+	message := err.Error()
+	errorRange := parser.Range{Start: 0, End: doc.lineEnd(0)}
+	longestMatch := 0
+	for _, outline := range doc.outlines {
+		line := strings.TrimSpace(string(doc.text[outline.Line.Start:outline.Line.End]))
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "AUTH_TOKEN" {
+			fields = fields[1:]
+		}
+		if len(fields) < 2 {
+			continue
+		}
+		// The errors only contain the path of the URL, without the query
+		path := fields[1]
+		if schemeEnd := strings.Index(path, "://"); schemeEnd >= 0 {
+			path = path[schemeEnd+len("://"):]
+			if pathStart := strings.Index(path, "/"); pathStart >= 0 {
+				path = path[pathStart:]
+			}
+		}
+		path = strings.SplitN(path, "?", 2)[0]
+		description := fields[0] + " " + path
+		if strings.Contains(message, description) && len(description) > longestMatch {
+			errorRange = outline.Line
+			longestMatch = len(description)
+		}
+	}
+	return errorRange
+}
+
+// specPositionRange returns the range of the key of the value at the position, or its body mark
+// or endpoint line if the position is not inside a body
+func (doc *document) specPositionRange(position gen.SpecPosition) parser.Range {
+	if position.Endpoint < 0 || position.Endpoint >= len(doc.outlines) {
+		return parser.Range{Start: 0, End: doc.lineEnd(0)}
+	}
+	outline := doc.outlines[position.Endpoint]
+	var positionBody *parser.BodyOutline
+	for _, body := range bodies(outline) {
+		if body.name == position.Body {
+			positionBody = body.BodyOutline
+		}
+	}
+	if positionBody == nil {
+		return outline.Line
+	}
+	for _, value := range doc.values {
+		if value.position == position {
+			if value.key.End > value.key.Start {
+				return value.key
+			}
+			if position.Path != "" {
+				return value.value
+			}
+		}
+	}
+	return positionBody.Mark
+}
+
+// hover returns the model and type of the property whose key is at the offset, if any
+func (doc *document) hover(offset int) *Hover {
+	if doc.generator == nil {
+		return nil
+	}
+	var hovered *jsonValue
+	for i, value := range doc.values {
+		if value.key.Start <= offset && offset < value.key.End {
+			hovered = &doc.values[i]
+		}
+	}
+	if hovered == nil {
+		return nil
+	}
+	prop, found := doc.generator.PropertyAt(hovered.position)
+	if !found {
+		return nil
+	}
+	description := fmt.Sprintf("**%s**.%s: `%s`", prop.ModelName, prop.PropertyName, prop.Type)
+	if prop.IsOptional {
+		description += " (optional)"
+	}
+	if prop.IsNullable {
+		description += " (nullable)"
+	}
+	return &Hover{
+		Contents: markupContent{Kind: "markdown", Value: description},
+		Range:    doc.lspRange(hovered.key),
+	}
+}
+
+// definition returns where the model declared with the type or extends attribute at the offset
+// is first found, if any
+func (doc *document) definition(offset int) *Location {
+	if doc.generator == nil {
+		return nil
+	}
+	key, value := doc.attributeAt(offset)
+	if key != typeAttributeKey && key != extendsAttributeKey {
+		return nil
+	}
+	position, found := doc.generator.ModelPosition(value)
+	if !found {
+		return nil
+	}
+	return &Location{
+		URI:   doc.uri,
+		Range: doc.lspRange(doc.specPositionRange(position)),
+	}
+}
+
+// attributeAt returns the key and value of the attribute at the offset, found in the specification
+// of an endpoint, a body or a property
+func (doc *document) attributeAt(offset int) (key, value string) {
+	var specs []parser.Range
+	for _, outline := range doc.outlines {
+		specs = append(specs, outline.Spec)
+		for _, body := range bodies(outline) {
+			specs = append(specs, body.Spec)
+		}
+	}
+	for _, value := range doc.values {
+		if value.key.End > value.key.Start {
+			// The attributes of the properties follow the name, inside the quotes
+			keyText := string(doc.text[value.key.Start:value.key.End])
+			if separator := strings.Index(keyText, propertySpecSeparator); separator >= 0 {
+				specs = append(specs, parser.Range{Start: value.key.Start + separator + 1, End: value.key.End - 1})
+			}
+		}
+	}
+
+	for _, spec := range specs {
+		if offset < spec.Start || offset > spec.End {
+			continue
+		}
+		attrStart := spec.Start
+		for _, attr := range strings.Split(string(doc.text[spec.Start:spec.End]), attrSeparator) {
+			if attrStart <= offset && offset <= attrStart+len(attr) {
+				keyVal := strings.SplitN(attr, attrKeyValueSeparator, 2)
+				if len(keyVal) < 2 {
+					return strings.TrimSpace(keyVal[0]), ""
+				}
+				return strings.TrimSpace(keyVal[0]), strings.TrimSpace(keyVal[1])
+			}
+			attrStart += len(attr) + len(attrSeparator)
+		}
+	}
+	return "", ""
+}
+
+// completion returns the attribute keys that can be written at the offset, if it is where the key of an
+// attribute goes in the specification of an endpoint, a body or a property. It only looks at the line of the offset,
+// so it works even if the spec is not valid while typing
+func (doc *document) completion(offset int) []CompletionItem {
+	linePrefix := string(doc.text[doc.lineStarts[doc.lineAt(offset)]:offset])
+	trimmedPrefix := strings.TrimSpace(linePrefix)
+
+	var keys []string
+	var spec, detail string
+	if quote := openQuote(linePrefix); quote >= 0 {
+		// Inside a JSON key with the attributes after the name
+		beforeQuote := strings.TrimSpace(linePrefix[:quote])
+		nameAndAttributes := strings.SplitN(linePrefix[quote+1:], propertySpecSeparator, 2)
+		if len(nameAndAttributes) < 2 || (beforeQuote != "" && !strings.HasSuffix(beforeQuote, "{") && !strings.HasSuffix(beforeQuote, ",")) {
+			return nil
+		}
+		keys, spec, detail = gen.PropertyAttributeKeys, nameAndAttributes[1], "property attribute"
+	} else if (strings.HasPrefix(trimmedPrefix, "->") || strings.HasPrefix(trimmedPrefix, "<-")) && !strings.ContainsAny(trimmedPrefix, "{[") {
+		keys, spec, detail = gen.BodyAttributeKeys, trimmedPrefix[len("->"):], "body attribute"
+	} else if match := endpointPrefixRegexp.FindStringIndex(linePrefix); match != nil {
+		keys, spec, detail = gen.EndpointAttributeKeys, linePrefix[match[1]:], "endpoint attribute"
+	} else {
+		return nil
+	}
+
+	// Only the keys are completed, not the values
+	attributes := strings.Split(spec, attrSeparator)
+	if strings.Contains(attributes[len(attributes)-1], attrKeyValueSeparator) {
+		return nil
+	}
+	items := make([]CompletionItem, 0, len(keys))
+	for _, key := range keys {
+		items = append(items, CompletionItem{Label: key, Kind: propertyCompletionKind, Detail: detail})
+	}
+	return items
+}
+
+// openQuote returns the index of the quote opening the JSON string that is not closed in the text,
+// or -1 if all of them are closed
+func openQuote(text string) int {
+	quote := -1
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '"':
+			if quote < 0 {
+				quote = i
+			} else {
+				quote = -1
+			}
+		}
+	}
+	return quote
+}
+
+func (doc *document) lineAt(offset int) int {
+	return sort.Search(len(doc.lineStarts), func(i int) bool { return doc.lineStarts[i] > offset }) - 1
+}
+
+func (doc *document) lineEnd(line int) int {
+	if line+1 < len(doc.lineStarts) {
+		return doc.lineStarts[line+1] - 1
+	}
+	return len(doc.text)
+}
+
+// offset returns the offset of the position, counting its characters in UTF-16 code units
+func (doc *document) offset(position Position) int {
+	if position.Line < 0 {
+		return 0
+	}
+	if position.Line >= len(doc.lineStarts) {
+		return len(doc.text)
+	}
+	offset := doc.lineStarts[position.Line]
+	for units := 0; units < position.Character && offset < doc.lineEnd(position.Line); {
+		r, size := utf8.DecodeRune(doc.text[offset:])
+		units += utf16Len(r)
+		offset += size
+	}
+	return offset
+}
+
+func (doc *document) position(offset int) Position {
+	line := doc.lineAt(offset)
+	character := 0
+	for _, r := range string(doc.text[doc.lineStarts[line]:offset]) {
+		character += utf16Len(r)
+	}
+	return Position{Line: line, Character: character}
+}
+
+func (doc *document) lspRange(r parser.Range) Range {
+	return Range{Start: doc.position(r.Start), End: doc.position(r.End)}
+}
+
+// utf16Len returns the number of UTF-16 code units of the rune
+func utf16Len(r rune) int {
+	if r >= 0x10000 {
+		return 2
+	}
+	return 1
+}
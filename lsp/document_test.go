@@ -0,0 +1,25 @@
+package lsp
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/alvaroloes/sdkgen/gen"
+)
+
+// TestDocumentPrefixes analyzes every prefix of a spec, as the server does while it is being typed
+func TestDocumentPrefixes(t *testing.T) {
+	spec, err := ioutil.ReadFile("../testFiles/api.sas")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i <= len(spec); i++ {
+		doc := newDocument(specURI, spec[:i], gen.Config{})
+		for _, diagnostic := range doc.diagnostics {
+			if strings.HasPrefix(diagnostic.Message, analysisFailureMessage) {
+				t.Fatalf("Failed analyzing the spec up to offset %d: %s", i, diagnostic.Message)
+			}
+		}
+	}
+}
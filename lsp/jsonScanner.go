@@ -0,0 +1,143 @@
+package lsp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alvaroloes/sdkgen/gen"
+	"github.com/alvaroloes/sdkgen/parser"
+)
+
+// jsonScanner finds where the values of a JSON body are, along with their paths. The paths have
+// the same format as the positions of the models, so the property names are the keys without their attributes.
+// It stops at the first invalid character, keeping the values found so far
+type jsonScanner struct {
+	text     []byte
+	position gen.SpecPosition
+	values   []jsonValue
+}
+
+// scanValue scans the value starting at the offset, returning the offset after it and whether it is valid
+func (s *jsonScanner) scanValue(offset int, path string, key parser.Range) (int, bool) {
+	offset = s.skipSpace(offset)
+	if offset >= len(s.text) {
+		return offset, false
+	}
+	start := offset
+	var valid bool
+	switch s.text[offset] {
+	case '{':
+		offset, valid = s.scanObject(offset, path)
+	case '[':
+		offset, valid = s.scanArray(offset, path)
+	case '"':
+		offset, valid = s.scanString(offset)
+	default:
+		for offset < len(s.text) && !strings.ContainsRune(",:{}[]\" \t\r\n", rune(s.text[offset])) {
+			offset++
+		}
+		valid = offset > start
+	}
+	if !valid {
+		return offset, false
+	}
+	position := s.position
+	position.Path = path
+	s.values = append(s.values, jsonValue{
+		position: position,
+		key:      key,
+		value:    parser.Range{Start: start, End: offset},
+	})
+	return offset, true
+}
+
+func (s *jsonScanner) scanObject(offset int, path string) (int, bool) {
+	offset = s.skipSpace(offset + 1)
+	if offset < len(s.text) && s.text[offset] == '}' {
+		return offset + 1, true
+	}
+	for {
+		keyStart := s.skipSpace(offset)
+		keyEnd, valid := s.scanString(keyStart)
+		if !valid {
+			return keyEnd, false
+		}
+		offset = s.skipSpace(keyEnd)
+		if offset >= len(s.text) || s.text[offset] != ':' {
+			return offset, false
+		}
+		rawKey := string(s.text[keyStart+1 : keyEnd-1])
+		name := strings.TrimSpace(strings.SplitN(rawKey, propertySpecSeparator, 2)[0])
+		propPath := name
+		if path != "" {
+			propPath = path + "." + name
+		}
+		offset, valid = s.scanValue(offset+1, propPath, parser.Range{Start: keyStart, End: keyEnd})
+		if !valid {
+			return offset, false
+		}
+		offset = s.skipSpace(offset)
+		if offset >= len(s.text) {
+			return offset, false
+		}
+		switch s.text[offset] {
+		case ',':
+			offset++
+		case '}':
+			return offset + 1, true
+		default:
+			return offset, false
+		}
+	}
+}
+
+func (s *jsonScanner) scanArray(offset int, path string) (int, bool) {
+	offset = s.skipSpace(offset + 1)
+	if offset < len(s.text) && s.text[offset] == ']' {
+		return offset + 1, true
+	}
+	for i := 0; ; i++ {
+		var valid bool
+		offset, valid = s.scanValue(offset, fmt.Sprintf("%s[%d]", path, i), parser.Range{})
+		if !valid {
+			return offset, false
+		}
+		offset = s.skipSpace(offset)
+		if offset >= len(s.text) {
+			return offset, false
+		}
+		switch s.text[offset] {
+		case ',':
+			offset++
+		case ']':
+			return offset + 1, true
+		default:
+			return offset, false
+		}
+	}
+}
+
+// scanString returns the offset after the closing quote of the string starting at the offset
+func (s *jsonScanner) scanString(offset int) (int, bool) {
+	if offset >= len(s.text) || s.text[offset] != '"' {
+		return offset, false
+	}
+	for offset++; offset < len(s.text); offset++ {
+		switch s.text[offset] {
+		case '\\':
+			offset++
+		case '"':
+			return offset + 1, true
+		case '\n':
+			return offset, false
+		}
+	}
+	return offset, false
+}
+
+func (s *jsonScanner) skipSpace(offset int) int {
+	for offset < len(s.text) && strings.ContainsRune(" \t\r\n", rune(s.text[offset])) {
+		offset++
+	}
+	return offset
+}
@@ -0,0 +1,57 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+const contentLengthHeader = "content-length"
+
+// readMessage reads the content of the next message, skipping its headers
+func readMessage(reader *bufio.Reader) ([]byte, error) {
+	contentLength := -1
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+		nameAndValue := strings.SplitN(line, ":", 2)
+		if len(nameAndValue) == 2 && strings.ToLower(strings.TrimSpace(nameAndValue[0])) == contentLengthHeader {
+			contentLength, err = strconv.Atoi(strings.TrimSpace(nameAndValue[1]))
+			if err != nil {
+				return nil, errors.Annotatef(ErrInvalidMessage, "invalid content length %q", nameAndValue[1])
+			}
+		}
+	}
+	if contentLength < 0 {
+		return nil, errors.Annotate(ErrInvalidMessage, "the message has no content length")
+	}
+	content := make([]byte, contentLength)
+	if _, err := io.ReadFull(reader, content); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return content, nil
+}
+
+// writeMessage writes the message encoded in JSON along with its headers
+func writeMessage(writer io.Writer, message interface{}) error {
+	content, err := json.Marshal(message)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := fmt.Fprintf(writer, "Content-Length: %d\r\n\r\n", len(content)); err != nil {
+		return errors.Trace(err)
+	}
+	_, err = writer.Write(content)
+	return errors.Trace(err)
+}
@@ -0,0 +1,158 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+
+	"github.com/alvaroloes/sdkgen/gen"
+	"github.com/juju/errors"
+)
+
+const serverName = "sdkgen"
+
+var (
+	ErrInvalidMessage = errors.New("invalid message")
+)
+
+// Server is a language server of the API spec format. It reports the problems of the open specs
+// as they change, shows the models of the properties and completes the attribute keys
+type Server struct {
+	config    gen.Config
+	documents map[string]*document
+	writer    io.Writer
+}
+
+// NewServer returns a server extracting the models with the config
+func NewServer(config gen.Config) *Server {
+	return &Server{
+		config:    config,
+		documents: map[string]*document{},
+	}
+}
+
+// Serve handles the messages read from the reader, writing the responses and notifications to the writer,
+// until the exit notification is received or the reader is closed
+func (s *Server) Serve(reader io.Reader, writer io.Writer) error {
+	s.writer = writer
+	bufReader := bufio.NewReader(reader)
+	for {
+		content, err := readMessage(bufReader)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		var req request
+		if err := json.Unmarshal(content, &req); err != nil {
+			return errors.Annotate(ErrInvalidMessage, err.Error())
+		}
+		if req.Method == "exit" {
+			return nil
+		}
+		if err := s.handle(req); err != nil {
+			return errors.Annotatef(err, "while handling %q", req.Method)
+		}
+	}
+}
+
+func (s *Server) handle(req request) error {
+	result, respErr := s.dispatch(req)
+	if req.isNotification() {
+		return nil
+	}
+	if respErr != nil {
+		return writeMessage(s.writer, errorResponse{JSONRPC: jsonRPCVersion, ID: req.ID, Error: *respErr})
+	}
+	return writeMessage(s.writer, resultResponse{JSONRPC: jsonRPCVersion, ID: req.ID, Result: result})
+}
+
+// dispatch handles the request, returning the result of the response
+func (s *Server) dispatch(req request) (interface{}, *responseError) {
+	switch req.Method {
+	case "initialize":
+		return initializeResult{
+			Capabilities: serverCapabilities{
+				TextDocumentSync:   fullTextDocumentSync,
+				HoverProvider:      true,
+				DefinitionProvider: true,
+				CompletionProvider: completionOptions{
+					TriggerCharacters: []string{propertySpecSeparator, attrSeparator, " "},
+				},
+			},
+			ServerInfo: serverInfo{Name: serverName},
+		}, nil
+	case "initialized", "shutdown":
+		return nil, nil
+	case "textDocument/didOpen":
+		var params didOpenTextDocumentParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		return nil, s.update(params.TextDocument.URI, params.TextDocument.Text)
+	case "textDocument/didChange":
+		var params didChangeTextDocumentParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		// The changes have the whole text as the sync is full, so only the last one matters
+		if len(params.ContentChanges) == 0 {
+			return nil, nil
+		}
+		return nil, s.update(params.TextDocument.URI, params.ContentChanges[len(params.ContentChanges)-1].Text)
+	case "textDocument/didClose":
+		var params didCloseTextDocumentParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		delete(s.documents, params.TextDocument.URI)
+		return nil, s.publishDiagnostics(params.TextDocument.URI, []Diagnostic{})
+	case "textDocument/hover", "textDocument/definition", "textDocument/completion":
+		var params textDocumentPositionParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		doc, found := s.documents[params.TextDocument.URI]
+		if !found {
+			return nil, nil
+		}
+		offset := doc.offset(params.Position)
+		switch req.Method {
+		case "textDocument/hover":
+			return doc.hover(offset), nil
+		case "textDocument/definition":
+			return doc.definition(offset), nil
+		default:
+			return doc.completion(offset), nil
+		}
+	}
+	return nil, &responseError{Code: methodNotFoundCode, Message: "method not found: " + req.Method}
+}
+
+// update analyzes the new text of the document and publishes its diagnostics
+func (s *Server) update(uri, text string) *responseError {
+	doc := newDocument(uri, []byte(text), s.config)
+	s.documents[uri] = doc
+	diagnostics := doc.diagnostics
+	if diagnostics == nil {
+		diagnostics = []Diagnostic{}
+	}
+	return s.publishDiagnostics(uri, diagnostics)
+}
+
+func (s *Server) publishDiagnostics(uri string, diagnostics []Diagnostic) *responseError {
+	err := writeMessage(s.writer, notification{
+		JSONRPC: jsonRPCVersion,
+		Method:  "textDocument/publishDiagnostics",
+		Params:  publishDiagnosticsParams{URI: uri, Diagnostics: diagnostics},
+	})
+	if err != nil {
+		return &responseError{Code: internalErrorCode, Message: err.Error()}
+	}
+	return nil
+}
+
+func invalidParams(err error) *responseError {
+	return &responseError{Code: invalidParamsCode, Message: err.Error()}
+}
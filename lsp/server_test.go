@@ -0,0 +1,172 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/alvaroloes/sdkgen/gen"
+)
+
+const specURI = "file:///api.sas"
+
+var serverSpec = `
+GET https://www.alvarloes.com/posts/:id
+	<- {
+		"id": 1,
+		"title": "Title",
+		"author: type = user; ": {
+			"id": 2,
+			"fullName": "Name"
+		}
+	}
+
+GET https://www.alvarloes.com/users/:id
+	<- type = user
+`
+
+var invalidServerSpec = `
+GET https://www.alvarloes.com/posts/:id
+	<- {
+		"id": 1,
+		"title" "Title"
+	}
+`
+
+type testMessage struct {
+	ID     *int            `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *responseError  `json:"error"`
+}
+
+// specPosition returns the position of the first occurrence of the text in the spec, plus the offset
+func specPosition(spec, text string, offset int) Position {
+	index := strings.Index(spec, text) + offset
+	lineStart := strings.LastIndex(spec[:index], "\n") + 1
+	return Position{Line: strings.Count(spec[:index], "\n"), Character: index - lineStart}
+}
+
+func TestServer(t *testing.T) {
+	var input bytes.Buffer
+	requestID := 0
+	send := func(method string, params interface{}) {
+		msg := map[string]interface{}{"jsonrpc": jsonRPCVersion, "method": method, "params": params}
+		if !strings.HasPrefix(method, "textDocument/did") && method != "initialized" && method != "exit" {
+			requestID++
+			msg["id"] = requestID
+		}
+		if err := writeMessage(&input, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	document := map[string]string{"uri": specURI}
+	positionParams := func(text string, offset int) interface{} {
+		return map[string]interface{}{"textDocument": document, "position": specPosition(serverSpec, text, offset)}
+	}
+
+	send("initialize", map[string]interface{}{})
+	send("initialized", map[string]interface{}{})
+	send("textDocument/didOpen", map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": specURI, "languageId": "sas", "version": 1, "text": serverSpec},
+	})
+	send("textDocument/hover", positionParams(`"fullName"`, 2))
+	send("textDocument/definition", positionParams("type = user\n", 2))
+	send("textDocument/completion", positionParams("type = user; ", len("type = user; ")))
+	send("textDocument/completion", positionParams("type = user; ", len("type = u")))
+	send("unknown/method", map[string]interface{}{})
+	send("textDocument/didChange", map[string]interface{}{
+		"textDocument":   document,
+		"contentChanges": []map[string]string{{"text": invalidServerSpec}},
+	})
+	send("shutdown", nil)
+	send("exit", nil)
+
+	var output bytes.Buffer
+	if err := NewServer(gen.Config{}).Serve(&input, &output); err != nil {
+		t.Fatal(err)
+	}
+	var messages []testMessage
+	reader := bufio.NewReader(&output)
+	for reader.Buffered() > 0 || output.Len() > 0 {
+		content, err := readMessage(reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var msg testMessage
+		if err := json.Unmarshal(content, &msg); err != nil {
+			t.Fatal(err)
+		}
+		messages = append(messages, msg)
+	}
+
+	expectedMethods := []string{"", "textDocument/publishDiagnostics", "", "", "", "", "", "textDocument/publishDiagnostics", ""}
+	if len(messages) != len(expectedMethods) {
+		t.Fatalf("Expected %d messages, got %d: %+v", len(expectedMethods), len(messages), messages)
+	}
+	for i, msg := range messages {
+		if msg.Method != expectedMethods[i] {
+			t.Errorf("Expected message %d to be %q, got %q", i, expectedMethods[i], msg.Method)
+		}
+	}
+
+	var initialize initializeResult
+	json.Unmarshal(messages[0].Result, &initialize)
+	if !initialize.Capabilities.HoverProvider || initialize.Capabilities.TextDocumentSync != fullTextDocumentSync {
+		t.Errorf("Unexpected capabilities: %s", messages[0].Result)
+	}
+
+	var diagnostics publishDiagnosticsParams
+	json.Unmarshal(messages[1].Params, &diagnostics)
+	if len(diagnostics.Diagnostics) != 1 || diagnostics.Diagnostics[0].Code != gen.LintNoBodyExamples {
+		t.Fatalf("Expected a warning about the endpoint without examples, got: %+v", diagnostics.Diagnostics)
+	}
+	if start := diagnostics.Diagnostics[0].Range.Start; start != specPosition(serverSpec, "GET https://www.alvarloes.com/users", 0) {
+		t.Errorf("Expected the warning at the endpoint line, got: %+v", start)
+	}
+
+	var hover Hover
+	json.Unmarshal(messages[2].Result, &hover)
+	if !strings.Contains(hover.Contents.Value, "**user**") || !strings.Contains(hover.Contents.Value, "fullName") {
+		t.Errorf("Expected the hover to show the model and the property, got: %q", hover.Contents.Value)
+	}
+	if hover.Range.Start != specPosition(serverSpec, `"fullName"`, 0) {
+		t.Errorf("Expected the hover at the key, got: %+v", hover.Range)
+	}
+
+	var location Location
+	json.Unmarshal(messages[3].Result, &location)
+	if location.URI != specURI || location.Range.Start != specPosition(serverSpec, `"author`, 0) {
+		t.Errorf("Expected the definition at the author property, got: %+v", location)
+	}
+
+	var items []CompletionItem
+	json.Unmarshal(messages[4].Result, &items)
+	labels := map[string]bool{}
+	for _, item := range items {
+		labels[item.Label] = true
+	}
+	for _, key := range []string{"name", "type", "map", "raw"} {
+		if !labels[key] {
+			t.Errorf("Expected the %q key to be completed, got: %+v", key, items)
+		}
+	}
+	if string(messages[5].Result) != "null" {
+		t.Errorf("Expected no completion of attribute values, got: %s", messages[5].Result)
+	}
+
+	if messages[6].Error == nil || messages[6].Error.Code != methodNotFoundCode {
+		t.Errorf("Expected a method not found error, got: %+v", messages[6])
+	}
+
+	json.Unmarshal(messages[7].Params, &diagnostics)
+	if len(diagnostics.Diagnostics) != 1 || diagnostics.Diagnostics[0].Severity != errorSeverity {
+		t.Fatalf("Expected an error in the invalid spec, got: %+v", diagnostics.Diagnostics)
+	}
+	if start := diagnostics.Diagnostics[0].Range.Start; start != specPosition(invalidServerSpec, `"Title"`, 0) {
+		t.Errorf("Expected the error at the invalid character, got: %+v", start)
+	}
+}
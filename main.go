@@ -1,15 +1,28 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
 	"io/ioutil"
+	"os"
+	"strings"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/alvaroloes/sdkgen/gen"
+	"github.com/alvaroloes/sdkgen/lsp"
 	"github.com/alvaroloes/sdkgen/parser"
+	"github.com/alvaroloes/sdkgen/watch"
 	"github.com/juju/errors"
 )
 
 func main() {
+	dryRun := flag.Bool("dry-run", false, "Print the changes the generation would make in the output directory without writing any file. Exits with status 1 if there is any")
+	keepStaleFiles := flag.Bool("keep-stale-files", false, "Only report the files generated by a previous run that are not generated anymore, instead of removing them")
+	flag.Parse()
+
 	log.SetLevel(log.DebugLevel)
 	// This will be extracted from command line flags
 	//config := gen.Config{
@@ -27,24 +40,167 @@ func main() {
 		ModelsRelPath:   "Models",
 		ServicesRelPath: "Services",
 		OutputDir:       "./testFiles",
+		DryRun:          *dryRun,
+		KeepStaleFiles:  *keepStaleFiles,
+	}
+
+	if flag.Arg(0) == "lsp" {
+		// The specs are read from the editor, which talks to the server through the standard input and output
+		if err := lsp.NewServer(config).Serve(os.Stdin, os.Stdout); err != nil {
+			log.Fatal(errors.ErrorStack(err))
+		}
+		return
 	}
 
-	specBytes, err := ioutil.ReadFile("./testFiles/api.sas")
+	specPath := "./testFiles/api.sas"
+	if flag.Arg(0) == "watch" {
+		watchSpec(specPath, config, flag.Args()[1:])
+		return
+	}
+
+	specBytes, err := ioutil.ReadFile(specPath)
 	if err != nil {
 		log.Fatal(errors.Annotate(err, "when reading API spec file"))
 	}
 
+	if flag.Arg(0) == "fmt" {
+		os.Exit(format(specPath, specBytes, flag.Args()[1:]))
+	}
+
 	api, err := parser.NewAPI(specBytes)
 	if err != nil {
 		log.Fatal(errors.ErrorStack(err))
 	}
 
-	gen, err := gen.New(gen.ObjC, api, config)
+	generator, err := gen.New(gen.ObjC, api, config)
 	if err != nil {
 		log.Fatal(errors.ErrorStack(err))
 	}
 
-	if err := gen.Generate(); err != nil {
+	if flag.Arg(0) == "lint" {
+		os.Exit(lint(generator, flag.Args()[1:]))
+	}
+
+	err = generator.Generate()
+	if errors.Cause(err) == gen.ErrOutputChanged {
+		for _, change := range generator.Changes() {
+			fmt.Print(change.Diff)
+		}
+		log.Error(err)
+		os.Exit(1)
+	}
+	if err != nil {
+		log.Fatal(errors.ErrorStack(err))
+	}
+}
+
+// lint prints the problems found in the API spec and returns the exit status: 1 if there is any
+func lint(generator gen.Generator, args []string) int {
+	flags := flag.NewFlagSet("lint", flag.ExitOnError)
+	asJSON := flags.Bool("json", false, "Print the problems as a JSON array")
+	flags.Parse(args)
+
+	issues, err := generator.Lint()
+	if err != nil {
+		log.Fatal(errors.ErrorStack(err))
+	}
+	if *asJSON {
+		if issues == nil {
+			issues = []gen.LintIssue{}
+		}
+		output, err := json.MarshalIndent(issues, "", "  ")
+		if err != nil {
+			log.Fatal(errors.ErrorStack(err))
+		}
+		fmt.Println(string(output))
+	} else {
+		for _, issue := range issues {
+			fmt.Println(issue)
+		}
+	}
+	if len(issues) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// format rewrites the API spec file formatted, or only checks whether it is. It returns the exit status:
+// 1 if the spec is not formatted in check mode
+func format(specPath string, specBytes []byte, args []string) int {
+	flags := flag.NewFlagSet("fmt", flag.ExitOnError)
+	check := flags.Bool("check", false, "Don't modify the spec file. Exits with status 1 if it is not formatted")
+	flags.Parse(args)
+
+	formatted, err := parser.Format(specBytes)
+	if err != nil {
 		log.Fatal(errors.ErrorStack(err))
 	}
+	if bytes.Equal(formatted, specBytes) {
+		return 0
+	}
+	if *check {
+		log.Errorf("%s is not formatted. Run the fmt command to format it", specPath)
+		return 1
+	}
+	info, err := os.Stat(specPath)
+	if err != nil {
+		log.Fatal(errors.Annotate(err, "when reading API spec file"))
+	}
+	if err := ioutil.WriteFile(specPath, formatted, info.Mode()); err != nil {
+		log.Fatal(errors.Annotate(err, "when writing API spec file"))
+	}
+	return 0
+}
+
+// watchSpec generates the SDK each time the API spec or the templates change, until the program is interrupted.
+// The errors are logged without exiting, so that they can be fixed while watching
+func watchSpec(specPath string, config gen.Config, args []string) {
+	flags := flag.NewFlagSet("watch", flag.ExitOnError)
+	interval := flags.Duration("interval", 500*time.Millisecond, "How often the files are checked for changes")
+	debounce := flags.Duration("debounce", 300*time.Millisecond, "How long the files must stay unchanged before generating, so that several saves in a row generate once")
+	flags.Parse(args)
+
+	templateDir := gen.TemplateDir(gen.ObjC)
+	generateSDK(specPath, config)
+	log.Infof("Watching %s and %s for changes", specPath, templateDir)
+	watch.New([]string{specPath, templateDir}, *interval, *debounce).Watch(nil, func(changedPaths []string) {
+		log.Infof("Changed: %s", strings.Join(changedPaths, ", "))
+		generateSDK(specPath, config)
+	})
+}
+
+// generateSDK reads the API spec and generates the SDK, logging the errors found
+func generateSDK(specPath string, config gen.Config) {
+	// A spec the generator can't handle must not stop the watch
+	defer func() {
+		if r := recover(); r != nil {
+			log.Errorf("the SDK can't be generated: %v", r)
+		}
+	}()
+	specBytes, err := ioutil.ReadFile(specPath)
+	if err != nil {
+		log.Error(errors.Annotate(err, "when reading API spec file"))
+		return
+	}
+	api, err := parser.NewAPI(specBytes)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	generator, err := gen.New(gen.ObjC, api, config)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	err = generator.Generate()
+	if errors.Cause(err) == gen.ErrOutputChanged {
+		for _, change := range generator.Changes() {
+			fmt.Print(change.Diff)
+		}
+	}
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	log.Info("Generated the SDK")
 }
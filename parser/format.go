@@ -0,0 +1,223 @@
+package parser
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+)
+
+// Separators of the attributes in the endpoint, body and property specifications
+const (
+	propertySpecSeparator = ":"
+	attrSeparator         = ";"
+	attrKeyValueSeparator = "="
+)
+
+// formatIndent indents the bodies of the endpoints and the JSON values inside them
+const formatIndent = "\t"
+
+// markedBody is a body of an endpoint along with its mark
+type markedBody struct {
+	mark string
+	*BodyOutline
+}
+
+type markedBodiesByStart []markedBody
+
+func (b markedBodiesByStart) Len() int           { return len(b) }
+func (b markedBodiesByStart) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b markedBodiesByStart) Less(i, j int) bool { return b[i].Mark.Start < b[j].Mark.Start }
+
+// Format returns the spec printed canonically: one blank line between endpoints, the bodies indented
+// below their endpoint, the attributes evenly spaced and the JSON pretty printed keeping the key order.
+// The rest of the lines are comments, which are kept. The spec must be valid
+func Format(spec []byte) ([]byte, error) {
+	if _, err := NewAPI(spec); err != nil {
+		return nil, err
+	}
+
+	var formatted bytes.Buffer
+	endpointMatches := endpointRegexp.FindAllSubmatchIndex(spec, -1)
+	if len(endpointMatches) == 0 {
+		writeComments(&formatted, "", commentLines(spec))
+		return formatted.Bytes(), nil
+	}
+
+	// The comments after the bodies of an endpoint are printed before the next one
+	comments := commentLines(spec[:endpointMatches[0][endpointFullIndex]])
+	for i, outline := range Outline(spec) {
+		match := endpointMatches[i]
+		if i > 0 {
+			formatted.WriteByte('\n')
+		}
+		writeComments(&formatted, "", comments)
+
+		if match[authTokenIndex] >= 0 {
+			formatted.WriteString(authToken + " ")
+		}
+		urlString, endpointSpec := splitURLAndSpec(string(spec[match[urlIndex]:match[urlIndex+1]]))
+		formatted.WriteString(string(spec[match[methodIndex]:match[methodIndex+1]]) + " " + urlString)
+		if endpointSpec != "" {
+			formatted.WriteString(" " + formatAttributes(endpointSpec))
+		}
+		formatted.WriteByte('\n')
+		comments = formatBodies(&formatted, spec, outline)
+	}
+	if len(comments) > 0 {
+		formatted.WriteByte('\n')
+		writeComments(&formatted, "", comments)
+	}
+	return formatted.Bytes(), nil
+}
+
+// formatBodies prints the bodies of an endpoint and the comments between them.
+// The comments after the last body are returned
+func formatBodies(formatted *bytes.Buffer, spec []byte, outline EndpointOutline) []string {
+	var bodies []markedBody
+	if outline.Request != nil {
+		bodies = append(bodies, markedBody{"->", outline.Request})
+	}
+	if outline.Response != nil {
+		bodies = append(bodies, markedBody{"<-", outline.Response})
+	}
+	sort.Sort(markedBodiesByStart(bodies))
+
+	previousEnd := outline.Line.End
+	for _, body := range bodies {
+		writeComments(formatted, formatIndent, commentLines(spec[previousEnd:body.Mark.Start]))
+
+		formatted.WriteString(formatIndent + body.mark)
+		if bodySpec := string(spec[body.Spec.Start:body.Spec.End]); bodySpec != "" {
+			formatted.WriteString(" " + formatAttributes(bodySpec))
+		}
+		if body.JSON.End > body.JSON.Start {
+			formatted.WriteByte(' ')
+			formatJSON(formatted, spec[body.JSON.Start:body.JSON.End], formatIndent)
+		}
+		formatted.WriteByte('\n')
+		previousEnd = body.end()
+	}
+	return commentLines(spec[previousEnd:outline.End])
+}
+
+// commentLines returns the lines that are not blank, trimmed
+func commentLines(text []byte) []string {
+	var lines []string
+	for _, line := range strings.Split(string(text), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func writeComments(formatted *bytes.Buffer, indent string, comments []string) {
+	for _, comment := range comments {
+		formatted.WriteString(indent + comment + "\n")
+	}
+}
+
+// formatAttributes returns the attributes of an endpoint or body specification separated
+// by "; " and with their values separated by " = "
+func formatAttributes(spec string) string {
+	var attributes []string
+	for _, attr := range strings.Split(spec, attrSeparator) {
+		keyVal := strings.SplitN(attr, attrKeyValueSeparator, 2)
+		formattedAttr := strings.TrimSpace(keyVal[0])
+		if len(keyVal) > 1 {
+			formattedAttr += " " + attrKeyValueSeparator + " " + strings.TrimSpace(keyVal[1])
+		}
+		if formattedAttr != "" {
+			attributes = append(attributes, formattedAttr)
+		}
+	}
+	return strings.Join(attributes, attrSeparator+" ")
+}
+
+// formatPropertySpec returns the property specification of a JSON key with the
+// name followed by ": " and the formatted attributes
+func formatPropertySpec(propertySpec string) string {
+	nameAndAttributes := strings.SplitN(propertySpec, propertySpecSeparator, 2)
+	name := strings.TrimSpace(nameAndAttributes[0])
+	if len(nameAndAttributes) < 2 {
+		return name
+	}
+	return name + propertySpecSeparator + " " + formatAttributes(nameAndAttributes[1])
+}
+
+// formatJSON pretty prints the valid JSON value, with one element per line indented from the
+// prefix and the keys formatted as property specifications. The literals are copied verbatim
+func formatJSON(formatted *bytes.Buffer, value []byte, prefix string) {
+	// isObject tells whether each of the containers of the current value is an object
+	var isObject []bool
+	expectingKey := false
+	newLine := func() {
+		formatted.WriteString("\n" + prefix + strings.Repeat(formatIndent, len(isObject)))
+	}
+	for i := 0; i < len(value); i++ {
+		switch b := value[i]; b {
+		case ' ', '\t', '\n', '\r':
+		case '{', '[':
+			formatted.WriteByte(b)
+			closing := byte('}')
+			if b == '[' {
+				closing = ']'
+			}
+			if next := nextNonSpace(value, i+1); next < len(value) && value[next] == closing {
+				formatted.WriteByte(closing)
+				i = next
+				continue
+			}
+			isObject = append(isObject, b == '{')
+			expectingKey = b == '{'
+			newLine()
+		case '}', ']':
+			isObject = isObject[:len(isObject)-1]
+			newLine()
+			formatted.WriteByte(b)
+		case ',':
+			formatted.WriteByte(b)
+			expectingKey = isObject[len(isObject)-1]
+			newLine()
+		case ':':
+			formatted.WriteString(": ")
+		case '"':
+			end := stringLiteralEnd(value, i)
+			if expectingKey {
+				formatted.WriteString(`"` + formatPropertySpec(string(value[i+1:end])) + `"`)
+				expectingKey = false
+			} else {
+				formatted.Write(value[i : end+1])
+			}
+			i = end
+		default:
+			// Numbers, booleans and null
+			end := i
+			for end < len(value) && !strings.ContainsRune(" \t\n\r,:]}", rune(value[end])) {
+				end++
+			}
+			formatted.Write(value[i:end])
+			i = end - 1
+		}
+	}
+}
+
+func nextNonSpace(value []byte, from int) int {
+	for from < len(value) && strings.ContainsRune(" \t\n\r", rune(value[from])) {
+		from++
+	}
+	return from
+}
+
+// stringLiteralEnd returns the index of the closing quote of the JSON string starting at the index
+func stringLiteralEnd(value []byte, start int) int {
+	for i := start + 1; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return len(value) - 1
+}
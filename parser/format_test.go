@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"testing"
+)
+
+var formatTestCases = []struct {
+	name     string
+	spec     string
+	expected string
+}{
+	{
+		name: "Endpoint line and attributes",
+		spec: `   AUTH_TOKEN   POST https://www.alvarloes.com/posts/:id/publish   verb=publish ;resource =now;
+-> type=authRequest;map {"id":"1"}
+<-raw`,
+		expected: `AUTH_TOKEN POST https://www.alvarloes.com/posts/:id/publish verb = publish; resource = now
+	-> type = authRequest; map {
+		"id": "1"
+	}
+	<- raw
+`,
+	}, {
+		name: "JSON bodies",
+		spec: `GET https://www.alvarloes.com/posts
+<- [{"id" : 1,"author:type = person;name=authorazo;  map":{"isAdmin":false,"age":20.5},
+  "tags":[ ], "meta" : { }, "title": "A \"quoted\", title: value", "editor": null}]`,
+		expected: `GET https://www.alvarloes.com/posts
+	<- [
+		{
+			"id": 1,
+			"author: type = person; name = authorazo; map": {
+				"isAdmin": false,
+				"age": 20.5
+			},
+			"tags": [],
+			"meta": {},
+			"title": "A \"quoted\", title: value",
+			"editor": null
+		}
+	]
+`,
+	}, {
+		name: "Comments and blank lines",
+		spec: `// Posts API
+
+
+GET https://www.alvarloes.com/posts/:id
+	// The post
+	<- {"id": "1"}
+  // Comments of the post
+GET https://www.alvarloes.com/posts/:id/comments
+	<- content = text
+	-> {"text": "Text"}
+
+
+
+// The end`,
+		expected: `// Posts API
+GET https://www.alvarloes.com/posts/:id
+	// The post
+	<- {
+		"id": "1"
+	}
+
+// Comments of the post
+GET https://www.alvarloes.com/posts/:id/comments
+	<- content = text
+	-> {
+		"text": "Text"
+	}
+
+// The end
+`,
+	},
+}
+
+func TestFormat(t *testing.T) {
+	for _, test := range formatTestCases {
+		formatted, err := Format([]byte(test.spec))
+		if err != nil {
+			t.Errorf("Test %q: Unexpected error: %v", test.name, err)
+			continue
+		}
+		if string(formatted) != test.expected {
+			t.Errorf("Test %q: Expected the formatted spec:\n%s\ngot:\n%s", test.name, test.expected, formatted)
+			continue
+		}
+
+		// Formatting is idempotent
+		formattedAgain, err := Format(formatted)
+		if err != nil || string(formattedAgain) != test.expected {
+			t.Errorf("Test %q: Expected the formatted spec not to change, got: %v\n%s", test.name, err, formattedAgain)
+		}
+	}
+}
+
+func TestFormatInvalidSpec(t *testing.T) {
+	if _, err := Format([]byte("GET https://www.alvarloes.com/posts\n<- {\"id\": }")); err == nil {
+		t.Errorf("Expected an error formatting an invalid spec")
+	}
+}
@@ -0,0 +1,112 @@
+package parser
+
+import "strings"
+
+// Range is a part of the spec, from the offset of its first byte to the offset after its last byte
+type Range struct {
+	Start, End int
+}
+
+// EndpointOutline tells where the parts of an endpoint are in the spec
+type EndpointOutline struct {
+	// Line is the endpoint line, and Spec the specification following the URL in it
+	Line Range
+	Spec Range
+	// Request and Response are nil if the endpoint has no such body
+	Request  *BodyOutline
+	Response *BodyOutline
+	// End is where the data of the endpoint finishes: the next endpoint or the end of the spec
+	End int
+}
+
+// BodyOutline tells where the parts of a body are in the spec
+type BodyOutline struct {
+	// Mark is the "->" or "<-" mark starting the body
+	Mark Range
+	Spec Range
+	// JSON is empty if the body has no JSON
+	JSON Range
+}
+
+// end returns where the body finishes: the end of the JSON, or of the spec if there is no JSON
+func (b *BodyOutline) end() int {
+	if b.JSON.End > b.JSON.Start {
+		return b.JSON.End
+	}
+	if b.Spec.End > b.Spec.Start {
+		return b.Spec.End
+	}
+	return b.Mark.End
+}
+
+// Outline returns where the endpoints are in the spec, in the same order as the endpoints of the API.
+// The parts are found in the same way they are extracted when parsing, even if they are invalid
+func Outline(spec []byte) []EndpointOutline {
+	endpointMatches := endpointRegexp.FindAllSubmatchIndex(spec, -1)
+	outlines := make([]EndpointOutline, 0, len(endpointMatches))
+	for i, match := range endpointMatches {
+		outline := EndpointOutline{
+			Line: Range{Start: match[endpointFullIndex], End: match[endpointFullIndex+1]},
+			End:  len(spec),
+		}
+		// The full match starts with the blank lines before the endpoint
+		outline.Line.Start += len(spec[match[endpointFullIndex]:match[endpointFullIndex+1]]) -
+			len(strings.TrimLeft(string(spec[match[endpointFullIndex]:match[endpointFullIndex+1]]), " \t\r\n"))
+		if i < len(endpointMatches)-1 {
+			outline.End = endpointMatches[i+1][endpointFullIndex]
+		}
+
+		urlEnd := match[urlIndex]
+		urlPart := string(spec[match[urlIndex]:match[urlIndex+1]])
+		urlString, endpointSpec := splitURLAndSpec(urlPart)
+		urlEnd += strings.Index(urlPart, urlString) + len(urlString)
+		outline.Spec = textRange(spec, urlEnd, match[urlIndex+1], endpointSpec)
+
+		endpointData := spec[outline.Line.End:outline.End]
+		requestMatch := requestBodyMarkRegexp.FindIndex(endpointData)
+		responseMatch := responseBodyMarkRegexp.FindIndex(endpointData)
+		if requestMatch != nil {
+			outline.Request = bodyOutline(endpointData, outline.Line.End, requestMatch, responseMatch)
+		}
+		if responseMatch != nil {
+			outline.Response = bodyOutline(endpointData, outline.Line.End, responseMatch, requestMatch)
+		}
+		outlines = append(outlines, outline)
+	}
+	return outlines
+}
+
+// bodyOutline returns where the parts of the body whose mark is found in the endpoint data are.
+// The offset is where the endpoint data starts in the spec
+func bodyOutline(endpointData []byte, offset int, markMatch, otherMarkMatch []int) *BodyOutline {
+	// The mark match starts with the blank space before the mark
+	markEnd := markMatch[1]
+	body := &BodyOutline{
+		Mark: Range{Start: offset + markEnd - 2, End: offset + markEnd},
+	}
+	data := bodyData(endpointData, markMatch, otherMarkMatch)
+	from, to, err := jsonObjectBounds(data)
+	if from < 0 {
+		body.Spec = textRange(endpointData, markEnd, markEnd+len(data), firstLine(string(data)))
+	} else {
+		if err != nil {
+			// The JSON is not closed, so it takes the rest of the body
+			to = len(data) - 1
+		}
+		body.Spec = textRange(endpointData, markEnd, markEnd+from, strings.TrimSpace(string(data[:from])))
+		body.JSON = Range{Start: offset + markEnd + from, End: offset + markEnd + to + 1}
+	}
+	body.Spec.Start += offset
+	body.Spec.End += offset
+	return body
+}
+
+// textRange returns the range of the first occurrence of the text between the offsets.
+// It is empty at the start if the text is empty
+func textRange(spec []byte, from, to int, text string) Range {
+	if text == "" {
+		return Range{Start: from, End: from}
+	}
+	start := from + strings.Index(string(spec[from:to]), text)
+	return Range{Start: start, End: start + len(text)}
+}
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"bytes"
 	"encoding/json"
 	"net/url"
 	"regexp"
@@ -12,6 +13,7 @@ import (
 var (
 	ErrNoRootResource = errors.New("root REST resource not found")
 	ErrMultipleHosts  = errors.New("multiple hosts/scheme API is not supported")
+	ErrUnclosedJSON   = errors.New("JSON object or array not closed")
 )
 
 //go:generate enumer -type=HTTPMethod
@@ -46,6 +48,9 @@ var (
 
 const segmentParameterPrefix = ":"
 
+// formatSuffixes are the known response format extensions that can end an endpoint path (/posts.json)
+var formatSuffixes = []string{".json", ".xml"}
+
 type API struct {
 	BaseURL   string
 	Endpoints []Endpoint
@@ -74,11 +79,27 @@ type Endpoint struct {
 	Authenticates bool
 	Method        HTTPMethod
 	URL           *url.URL
-	Resources     []Resource
-	RequestSpec   string
-	RequestBody   interface{}
-	ResponseSpec  string
-	ResponseBody  interface{}
+	// FormatSuffix is the format extension stripped from the URL path, if any
+	FormatSuffix string
+	Spec         string
+	Resources    []Resource
+	RequestSpec  string
+	RequestBody  interface{}
+	ResponseSpec string
+	ResponseBody interface{}
+}
+
+// extractFormatSuffix strips the known format suffix from the URL path, so that
+// it is not taken as part of the resource or segment parameter name
+func (ep *Endpoint) extractFormatSuffix() {
+	for _, suffix := range formatSuffixes {
+		if strings.HasSuffix(strings.ToLower(ep.URL.Path), suffix) {
+			ep.FormatSuffix = ep.URL.Path[len(ep.URL.Path)-len(suffix):]
+			ep.URL.Path = ep.URL.Path[:len(ep.URL.Path)-len(suffix)]
+			ep.URL.RawPath = ""
+			return
+		}
+	}
 }
 
 func (ep *Endpoint) extractResources() error {
@@ -100,30 +121,51 @@ func (ep *Endpoint) extractResources() error {
 			})
 		}
 	}
+	if len(ep.Resources) == 0 {
+		return errors.Annotate(ErrNoRootResource, "in URL "+ep.URL.String())
+	}
 
 	return nil
 }
 
 func (ep *Endpoint) extractBodies(endpointData []byte) error {
-	match := requestBodyMarkRegexp.FindIndex(endpointData)
-	if match != nil {
+	requestMatch := requestBodyMarkRegexp.FindIndex(endpointData)
+	responseMatch := responseBodyMarkRegexp.FindIndex(endpointData)
+	if requestMatch != nil {
 		var requestBody []byte
-		ep.RequestSpec, requestBody = findSpecAndJSONObject(endpointData[match[1]:])
-		if err := json.Unmarshal(requestBody, &ep.RequestBody); err != nil {
+		var err error
+		ep.RequestSpec, requestBody, err = findSpecAndJSONObject(bodyData(endpointData, requestMatch, responseMatch))
+		if err == nil && requestBody != nil {
+			err = unmarshalJSON(requestBody, &ep.RequestBody)
+		}
+		if err != nil {
 			return errors.Annotate(err, "while parsing JSON request body of "+ep.URL.String())
 		}
 	}
-	match = responseBodyMarkRegexp.FindIndex(endpointData)
-	if match != nil {
+	if responseMatch != nil {
 		var responseBody []byte
-		ep.ResponseSpec, responseBody = findSpecAndJSONObject(endpointData[match[1]:])
-		if err := json.Unmarshal(responseBody, &ep.ResponseBody); err != nil {
+		var err error
+		ep.ResponseSpec, responseBody, err = findSpecAndJSONObject(bodyData(endpointData, responseMatch, requestMatch))
+		if err == nil && responseBody != nil {
+			err = unmarshalJSON(responseBody, &ep.ResponseBody)
+		}
+		if err != nil {
 			return errors.Annotate(err, "while parsing JSON response body of "+ep.URL.String())
 		}
 	}
 	return nil
 }
 
+// bodyData returns the data following the body mark, up to the mark of the other body
+// if it comes after it. This way a body without JSON doesn't take the JSON of the other one
+func bodyData(endpointData []byte, mark, otherMark []int) []byte {
+	end := len(endpointData)
+	if otherMark != nil && otherMark[0] > mark[1] {
+		end = otherMark[0]
+	}
+	return endpointData[mark[1]:end]
+}
+
 type Resource struct {
 	Name       string
 	Parameters []string
@@ -135,7 +177,9 @@ func NewAPI(spec []byte) (*API, error) {
 	for i, match := range endpointMatches {
 		endpoint := Endpoint{}
 
-		urlString := string(spec[match[urlIndex]:match[urlIndex+1]])
+		// The endpoint specification can follow the URL
+		urlString, endpointSpec := splitURLAndSpec(string(spec[match[urlIndex]:match[urlIndex+1]]))
+		endpoint.Spec = endpointSpec
 		parsedURL, err := url.Parse(urlString)
 		if err != nil {
 			return nil, errors.Annotate(err, "while parsing the URL "+urlString)
@@ -150,6 +194,7 @@ func NewAPI(spec []byte) (*API, error) {
 
 		endpoint.Authenticates = match[authTokenIndex] >= 0
 
+		endpoint.extractFormatSuffix()
 		if err := endpoint.extractResources(); err != nil {
 			return nil, errors.Annotate(err, "while extracting resources of "+endpoint.URL.String())
 		}
@@ -175,11 +220,56 @@ func NewAPI(spec []byte) (*API, error) {
 	return &api, nil
 }
 
+// splitURLAndSpec separates the URL from the specification that follows it in the endpoint line
+func splitURLAndSpec(line string) (string, string) {
+	line = strings.TrimSpace(line)
+	urlEnd := strings.IndexAny(line, " \t")
+	if urlEnd < 0 {
+		return line, ""
+	}
+	return line[:urlEnd], strings.TrimSpace(line[urlEnd:])
+}
+
+// unmarshalJSON works like json.Unmarshal, but numbers are decoded as json.Number instead
+// of float64. This preserves the number literals so that integers can be told apart from floats
+func unmarshalJSON(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
 // findSpecAndJSONObject returns a string with the specification and
-// a byte slice containing the first JSON object or array in the provided bytes
-func findSpecAndJSONObject(bytes []byte) (string, []byte) {
+// a byte slice containing the first JSON object or array in the provided bytes.
+// If there is no JSON object or array, the specification is the first line and the byte slice is nil
+func findSpecAndJSONObject(bytes []byte) (string, []byte, error) {
+	from, to, err := jsonObjectBounds(bytes)
+	if err != nil {
+		return "", nil, err
+	}
+	if from < 0 {
+		return firstLine(string(bytes)), nil, nil
+	}
+	return strings.TrimSpace(string(bytes[:from])), bytes[from : to+1], nil
+}
+
+// firstLine returns the first line of the text that is not blank, trimmed
+func firstLine(text string) string {
+	line := strings.TrimSpace(text)
+	if lineEnd := strings.IndexByte(line, '\n'); lineEnd >= 0 {
+		line = strings.TrimSpace(line[:lineEnd])
+	}
+	return line
+}
+
+// jsonObjectBounds returns the indexes of the opening and closing brackets of the first
+// JSON object or array in the provided bytes. Both are -1 if there is no JSON object or array.
+// ErrUnclosedJSON is returned, along with the index of the opening bracket, if it is not closed
+func jsonObjectBounds(bytes []byte) (from, to int, err error) {
 	var opening, closing byte
-	var from, to int
+
+	if !strings.ContainsAny(string(bytes), "{[") {
+		return -1, -1, nil
+	}
 
 	for i, b := range bytes {
 		if b == '{' || b == '[' {
@@ -202,10 +292,8 @@ func findSpecAndJSONObject(bytes []byte) (string, []byte) {
 			level--
 		}
 		if level <= 0 {
-			to = from + 1 + i
-			break
+			return from, from + 1 + i, nil
 		}
 	}
-
-	return strings.TrimSpace(string(bytes[:from])), bytes[from : to+1]
+	return from, -1, ErrUnclosedJSON
 }
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 
@@ -218,7 +219,7 @@ var testCases = []testCase{
 							"id": "1234",
 							"author": map[string]interface{}{
 								"name": "John",
-								"age":  float64(20),
+								"age":  json.Number("20"),
 							},
 							"title": "We really need a client SDK generator",
 							"body":  "(...) we to make the machine work for us, thus we should write generators to make the computer write the non-creative part of the code for us",
@@ -238,7 +239,7 @@ var testCases = []testCase{
 							"id": "12345",
 							"author": map[string]interface{}{
 								"name": "John",
-								"age":  float64(20),
+								"age":  json.Number("20"),
 							},
 							"title": "We really need a client SDK generator",
 							"body":  "(...) we to make the machine work for us, thus we should write generators to make the computer write the non-creative part of the code for us",
@@ -260,6 +261,81 @@ var testCases = []testCase{
 			},
 		},
 		expectedErr: nil,
+	}, {
+		name: "Endpoint specification after the URL",
+		spec: []byte(`POST https://www.alvarloes.com/posts/:id/publish   verb = publish; service = posts
+			<- {
+				"id":"4567"
+			}`),
+		expectedAPI: &API{
+			BaseURL: "https://www.alvarloes.com",
+			Endpoints: []Endpoint{
+				{
+					Method: POST,
+					URL:    tests.MustParseURL("https://www.alvarloes.com/posts/:id/publish"),
+					Spec:   "verb = publish; service = posts",
+					Resources: []Resource{
+						{
+							Name:       "posts",
+							Parameters: []string{"id"},
+						}, {
+							Name: "publish",
+						},
+					},
+					ResponseBody: map[string]interface{}{
+						"id": "4567",
+					},
+				},
+			},
+		},
+		expectedErr: nil,
+	}, {
+		name: "Non JSON bodies",
+		spec: []byte(`POST https://www.alvarloes.com/posts/:id/title
+			-> content = form
+			<- content = text`),
+		expectedAPI: &API{
+			BaseURL: "https://www.alvarloes.com",
+			Endpoints: []Endpoint{
+				{
+					Method: POST,
+					URL:    tests.MustParseURL("https://www.alvarloes.com/posts/:id/title"),
+					Resources: []Resource{
+						{
+							Name:       "posts",
+							Parameters: []string{"id"},
+						}, {
+							Name: "title",
+						},
+					},
+					RequestSpec:  "content = form",
+					ResponseSpec: "content = text",
+				},
+			},
+		},
+		expectedErr: nil,
+	}, {
+		name: "Format suffix",
+		spec: []byte(`GET https://www.alvarloes.com/posts/:id/comments.json`),
+		expectedAPI: &API{
+			BaseURL: "https://www.alvarloes.com",
+			Endpoints: []Endpoint{
+				{
+					Method:       GET,
+					URL:          tests.MustParseURL("https://www.alvarloes.com/posts/:id/comments"),
+					FormatSuffix: ".json",
+					Resources: []Resource{
+						{
+							Name:       "posts",
+							Parameters: []string{"id"},
+						}, {
+							Name: "comments",
+						},
+					},
+				},
+			},
+		},
+		expectedErr: nil,
 	},
 }
 
@@ -11,10 +11,19 @@
 
 // Models
 {{- range .AllModelsInfo }}
-{{- if .Properties }}
+{{- if .HasModelFile }}
 #import "{{.Name}}.h"
 {{- end}}
 {{- end}}
+{{- if .Config.ExtensionsRelPath}}
+
+// Extensions
+{{- range .AllModelsInfo }}
+{{- if .HasModelFile }}
+#import "{{.Name}}+Custom.h"
+{{- end}}
+{{- end}}
+{{- end}}
 
 //Protocols
 #import "{{.Config.APIPrefix}}ServiceProtocol.h"
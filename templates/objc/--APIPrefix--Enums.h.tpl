@@ -0,0 +1,22 @@
+{{template "preHeaderComment" .}}
+
+#import <Foundation/Foundation.h>
+{{range .AllEnumsInfo}}
+{{- $enum := .}}
+typedef NS_ENUM(NSInteger, {{$enum.Name}}) {
+    {{$enum.Name}}Unknown = 0,
+    {{- range $enum.Values}}
+    {{$enum.Name}}{{. | camelCase | upperFirst}},
+    {{- end}}
+};
+
+/**
+ * Returns the {{$enum.Name}} value corresponding to the string. {{$enum.Name}}Unknown is returned for unknown strings
+ */
+{{$enum.Name}} {{$enum.Name}}FromString(NSString *string);
+
+/**
+ * Returns the string corresponding to the {{$enum.Name}} value or nil for {{$enum.Name}}Unknown
+ */
+NSString *{{$enum.Name}}ToString({{$enum.Name}} value);
+{{end}}
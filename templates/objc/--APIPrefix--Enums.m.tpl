@@ -0,0 +1,33 @@
+{{template "preHeaderComment" .}}
+
+#import "{{.Config.APIPrefix}}Enums.h"
+{{range .AllEnumsInfo}}
+{{- $enum := .}}
+{{$enum.Name}} {{$enum.Name}}FromString(NSString *string)
+{
+    if (![string isKindOfClass:[NSString class]])
+    {
+        return {{$enum.Name}}Unknown;
+    }
+    {{- range $enum.Values}}
+    if ([string isEqualToString:@"{{.}}"])
+    {
+        return {{$enum.Name}}{{. | camelCase | upperFirst}};
+    }
+    {{- end}}
+    return {{$enum.Name}}Unknown;
+}
+
+NSString *{{$enum.Name}}ToString({{$enum.Name}} value)
+{
+    switch (value)
+    {
+        {{- range $enum.Values}}
+        case {{$enum.Name}}{{. | camelCase | upperFirst}}:
+            return @"{{.}}";
+        {{- end}}
+        default:
+            return nil;
+    }
+}
+{{end}}
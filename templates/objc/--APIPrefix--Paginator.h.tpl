@@ -0,0 +1,40 @@
+{{template "preHeaderComment" .}}
+
+#import <Foundation/Foundation.h>
+#import <PromiseKit/PromiseKit.h>
+
+typedef NS_ENUM(NSInteger, {{.Config.APIPrefix}}Pagination) {
+    // The page number, starting at 1
+    {{.Config.APIPrefix}}PaginationPage,
+    // The number of items to skip
+    {{.Config.APIPrefix}}PaginationOffset,
+    // The cursor found in the previous page
+    {{.Config.APIPrefix}}PaginationCursor,
+};
+
+/**
+ * Iterates the pages of a paginated endpoint, requesting each one after the previous one is handled
+ */
+@interface {{.Config.APIPrefix}}Paginator : NSObject
+
+@property (nonatomic, assign, readonly) {{.Config.APIPrefix}}Pagination pagination;
+@property (nonatomic, copy, readonly) NSString *param;
+@property (nonatomic, copy, readonly) NSString *pageSizeParam;
+@property (nonatomic, copy, readonly) NSString *itemsKeyPath;
+@property (nonatomic, copy, readonly) NSString *nextKeyPath;
+
++ (instancetype)paginatorWithPagination:({{.Config.APIPrefix}}Pagination)pagination
+                                  param:(NSString *)param
+                          pageSizeParam:(NSString *)pageSizeParam
+                           itemsKeyPath:(NSString *)itemsKeyPath
+                            nextKeyPath:(NSString *)nextKeyPath;
+
+/**
+ * Fetches the pages starting at the one of the query, until the last one is reached or onPage returns NO.
+ * onPage receives the unparsed response of each page. The promise is resolved when the iteration ends
+ */
+- (AnyPromise *)iteratePagesWithQuery:(NSDictionary *)query
+                            fetchPage:(AnyPromise *(^)(NSDictionary *pageQuery))fetchPage
+                               onPage:(BOOL (^)(id response))onPage;
+
+@end
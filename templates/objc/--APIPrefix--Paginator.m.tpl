@@ -0,0 +1,91 @@
+{{template "preHeaderComment" .}}
+
+#import "{{.Config.APIPrefix}}Paginator.h"
+
+@interface {{.Config.APIPrefix}}Paginator ()
+@property (nonatomic, assign, readwrite) {{.Config.APIPrefix}}Pagination pagination;
+@property (nonatomic, copy, readwrite) NSString *param;
+@property (nonatomic, copy, readwrite) NSString *pageSizeParam;
+@property (nonatomic, copy, readwrite) NSString *itemsKeyPath;
+@property (nonatomic, copy, readwrite) NSString *nextKeyPath;
+@end
+
+@implementation {{.Config.APIPrefix}}Paginator
+
++ (instancetype)paginatorWithPagination:({{.Config.APIPrefix}}Pagination)pagination
+                                  param:(NSString *)param
+                          pageSizeParam:(NSString *)pageSizeParam
+                           itemsKeyPath:(NSString *)itemsKeyPath
+                            nextKeyPath:(NSString *)nextKeyPath
+{
+    {{.Config.APIPrefix}}Paginator *paginator = [[{{.Config.APIPrefix}}Paginator alloc] init];
+    if (paginator != nil)
+    {
+        paginator.pagination = pagination;
+        paginator.param = param;
+        paginator.pageSizeParam = pageSizeParam;
+        paginator.itemsKeyPath = itemsKeyPath;
+        paginator.nextKeyPath = nextKeyPath;
+    }
+    return paginator;
+}
+
+- (AnyPromise *)iteratePagesWithQuery:(NSDictionary *)query
+                            fetchPage:(AnyPromise *(^)(NSDictionary *pageQuery))fetchPage
+                               onPage:(BOOL (^)(id response))onPage
+{
+    return fetchPage(query).then(^(id response) {
+        NSDictionary *nextQuery = [self nextPageQueryOf:query response:response];
+        if (!onPage(response) || nextQuery == nil)
+        {
+            return [AnyPromise promiseWithValue:nil];
+        }
+        return [self iteratePagesWithQuery:nextQuery fetchPage:fetchPage onPage:onPage];
+    });
+}
+
+#pragma mark - Private methods
+
+/**
+ * Returns the query of the page following the response or nil if it is the last one
+ */
+- (NSDictionary *)nextPageQueryOf:(NSDictionary *)query response:(id)response
+{
+    NSMutableDictionary *nextQuery = [NSMutableDictionary dictionaryWithDictionary:query];
+
+    if (self.pagination == {{.Config.APIPrefix}}PaginationCursor)
+    {
+        id cursor = [response isKindOfClass:[NSDictionary class]] ? [response valueForKeyPath:self.nextKeyPath] : nil;
+        if (cursor == nil || cursor == [NSNull null] || [cursor description].length == 0)
+        {
+            return nil;
+        }
+        nextQuery[self.param] = cursor;
+        return nextQuery;
+    }
+
+    id items = response;
+    if (self.itemsKeyPath.length > 0)
+    {
+        items = [response isKindOfClass:[NSDictionary class]] ? [response valueForKeyPath:self.itemsKeyPath] : nil;
+    }
+    NSUInteger count = [items isKindOfClass:[NSArray class]] ? [items count] : 0;
+    NSInteger pageSize = [query[self.pageSizeParam] integerValue];
+    if (count == 0 || (pageSize > 0 && count < pageSize))
+    {
+        return nil;
+    }
+
+    if (self.pagination == {{.Config.APIPrefix}}PaginationPage)
+    {
+        NSInteger page = query[self.param] != nil ? [query[self.param] integerValue] : 1;
+        nextQuery[self.param] = @(page + 1);
+    }
+    else
+    {
+        nextQuery[self.param] = @([query[self.param] integerValue] + count);
+    }
+    return nextQuery;
+}
+
+@end
@@ -7,6 +7,26 @@
 #import "{{.AuthInfo.Endpoint.ResponseModel.Name}}.h"
 {{- end}}
 
+/**
+ * How the request params are sent in the body
+ */
+typedef NS_ENUM(NSInteger, {{.Config.APIPrefix}}RequestFormat) {
+    {{.Config.APIPrefix}}RequestFormatJSON,
+    {{.Config.APIPrefix}}RequestFormatForm,
+    // NSURL params are sent as file parts
+    {{.Config.APIPrefix}}RequestFormatMultipart,
+};
+
+/**
+ * How the response body is parsed: JSON values, NSString (Text), NSNumber (Bool) or NSData (Binary)
+ */
+typedef NS_ENUM(NSInteger, {{.Config.APIPrefix}}ResponseFormat) {
+    {{.Config.APIPrefix}}ResponseFormatJSON,
+    {{.Config.APIPrefix}}ResponseFormatText,
+    {{.Config.APIPrefix}}ResponseFormatBool,
+    {{.Config.APIPrefix}}ResponseFormatBinary,
+};
+
 @interface {{.Config.APIPrefix}}ResourceManager : NSObject
 
 @property (nonatomic, copy) NSString *baseURL;
@@ -27,4 +47,10 @@
 - (AnyPromise *)deleteResourceWithURLPath:(NSString *)urlPath
                                    params:(NSDictionary *)params;
 
+- (AnyPromise *)requestResourceWithMethod:(NSString *)method
+                                  URLPath:(NSString *)urlPath
+                                   params:(NSDictionary *)params
+                            requestFormat:({{.Config.APIPrefix}}RequestFormat)requestFormat
+                           responseFormat:({{.Config.APIPrefix}}ResponseFormat)responseFormat;
+
 @end
@@ -9,6 +9,8 @@ static NSString *const kOAUTHCredentialIdentifier = @"{{.Config.APIPrefix}}OAUTH
 {{end}}
 @interface {{.Config.APIPrefix}}ResourceManager()
 @property (nonatomic, strong) AFHTTPSessionManager *sessionManager;
+// rawSessionManager doesn't parse the responses, as they are parsed per request
+@property (nonatomic, strong) AFHTTPSessionManager *rawSessionManager;
 {{- if .AuthInfo}}
 @property (nonatomic, strong) AFOAuthCredential *credential;
 {{- if .AuthInfo.RefreshTokenProp}}
@@ -29,6 +31,9 @@ static NSString *const kOAUTHCredentialIdentifier = @"{{.Config.APIPrefix}}OAUTH
         _sessionManager.requestSerializer = [AFJSONRequestSerializer serializer];
         [_sessionManager.requestSerializer setValue:@"application/json" forHTTPHeaderField:@"Accept"];
         [_sessionManager.requestSerializer setValue:@"application/json" forHTTPHeaderField:@"Content-Type"];
+        _rawSessionManager = [[AFHTTPSessionManager alloc] initWithBaseURL:[NSURL URLWithString:baseURL]];
+        _rawSessionManager.responseSerializer = [AFHTTPResponseSerializer serializer];
+        _rawSessionManager.responseSerializer.acceptableContentTypes = nil;
         {{if .AuthInfo -}}
         _credential = [AFOAuthCredential retrieveCredentialWithIdentifier:kOAUTHCredentialIdentifier];
         if (_credential != nil)
@@ -99,8 +104,119 @@ static NSString *const kOAUTHCredentialIdentifier = @"{{.Config.APIPrefix}}OAUTH
     {{- template "resourceManagerRequestPromiseCreation" "DELETE"}}
 }
 
+- (AnyPromise *)requestResourceWithMethod:(NSString *)method
+                                  URLPath:(NSString *)urlPath
+                                   params:(NSDictionary *)params
+                            requestFormat:({{.Config.APIPrefix}}RequestFormat)requestFormat
+                           responseFormat:({{.Config.APIPrefix}}ResponseFormat)responseFormat
+{
+    typeof (self) __weak weakSelf = self;
+    return [self doRequest:^AnyPromise * {
+                 typeof (self) __strong strongSelf = weakSelf;
+                 NSError *serializationError;
+                 NSURLRequest *request = [strongSelf requestWithMethod:method
+                                                               URLPath:urlPath
+                                                                params:params
+                                                         requestFormat:requestFormat
+                                                                 error:&serializationError];
+                 if (request == nil)
+                 {
+                     return [AnyPromise promiseWithValue:serializationError];
+                 }
+                 PMKResolver resolver;
+                 AnyPromise *requestPromise = [[AnyPromise alloc] initWithResolver:&resolver];
+                 NSURLSessionDataTask *task = [strongSelf.rawSessionManager dataTaskWithRequest:request
+                                                                                 uploadProgress:nil
+                                                                               downloadProgress:nil
+                                                                              completionHandler:^(NSURLResponse * _Nonnull response, id  _Nullable responseObject, NSError * _Nullable error) {
+                                                                                  NSHTTPURLResponse *httpResponse = (NSHTTPURLResponse *)response;
+                                                                                  if (error == nil)
+                                                                                  {
+                                                                                      responseObject = [{{.Config.APIPrefix}}ResourceManager parseResponseData:responseObject
+                                                                                                                             responseFormat:responseFormat
+                                                                                                                                      error:&error];
+                                                                                  }
+                                                                                  resolver(PMKManifold(error ?: responseObject, @(httpResponse.statusCode)));
+                                                                              }];
+                 [task resume];
+                 return requestPromise;
+             }];
+}
+
 #pragma mark - Private methods
 
+- (NSURLRequest *)requestWithMethod:(NSString *)method
+                            URLPath:(NSString *)urlPath
+                             params:(NSDictionary *)params
+                      requestFormat:({{.Config.APIPrefix}}RequestFormat)requestFormat
+                              error:(NSError **)error
+{
+    NSString *URLString = [[NSURL URLWithString:urlPath relativeToURL:self.rawSessionManager.baseURL] absoluteString];
+    AFHTTPRequestSerializer *serializer = requestFormat == {{.Config.APIPrefix}}RequestFormatJSON ? [AFJSONRequestSerializer serializer] : [AFHTTPRequestSerializer serializer];
+    [serializer setValue:[self.sessionManager.requestSerializer valueForHTTPHeaderField:@"Authorization"]
+      forHTTPHeaderField:@"Authorization"];
+
+    if (requestFormat != {{.Config.APIPrefix}}RequestFormatMultipart)
+    {
+        return [serializer requestWithMethod:method URLString:URLString parameters:params error:error];
+    }
+
+    // The file URLs are sent as file parts and the rest of params as form fields
+    NSMutableDictionary *fields = [NSMutableDictionary dictionary];
+    NSMutableDictionary *fileURLs = [NSMutableDictionary dictionary];
+    [params enumerateKeysAndObjectsUsingBlock:^(id key, id value, BOOL *stop) {
+        if ([value isKindOfClass:[NSURL class]])
+        {
+            fileURLs[key] = value;
+        }
+        else
+        {
+            fields[key] = value;
+        }
+    }];
+    __block NSError *fileError;
+    NSURLRequest *request = [serializer multipartFormRequestWithMethod:method
+                                                             URLString:URLString
+                                                            parameters:fields
+                                             constructingBodyWithBlock:^(id<AFMultipartFormData> formData) {
+                                                 [fileURLs enumerateKeysAndObjectsUsingBlock:^(NSString *name, NSURL *fileURL, BOOL *stop) {
+                                                     *stop = ![formData appendPartWithFileURL:fileURL name:name error:&fileError];
+                                                 }];
+                                             }
+                                                                 error:error];
+    if (fileError != nil)
+    {
+        if (error != NULL)
+        {
+            *error = fileError;
+        }
+        return nil;
+    }
+    return request;
+}
+
++ (id)parseResponseData:(NSData *)data
+         responseFormat:({{.Config.APIPrefix}}ResponseFormat)responseFormat
+                  error:(NSError **)error
+{
+    switch (responseFormat)
+    {
+        case {{.Config.APIPrefix}}ResponseFormatBinary:
+            return data;
+        case {{.Config.APIPrefix}}ResponseFormatText:
+            return [[NSString alloc] initWithData:data encoding:NSUTF8StringEncoding];
+        case {{.Config.APIPrefix}}ResponseFormatJSON:
+        case {{.Config.APIPrefix}}ResponseFormatBool:
+            if (data.length == 0)
+            {
+                return nil;
+            }
+            return [NSJSONSerialization JSONObjectWithData:data options:NSJSONReadingAllowFragments error:error];
+    }
+    return nil;
+}
+
+
 - (AnyPromise *)doRequest:(AnyPromise *(^)())requestBlock
 {
     {{- if .AuthInfo}}
@@ -19,4 +19,12 @@
  */
 - (NSDictionary *)toDictionary;
 
+@optional
+
+/**
+ * Returns the class (this one or a subclass) that must be instantiated for the dictionary.
+ * It's implemented by the models that have variants
+ */
++ (Class)classForDictionary:(NSDictionary *)dictionary;
+
 @end
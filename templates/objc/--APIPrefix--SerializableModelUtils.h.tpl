@@ -11,4 +11,34 @@
 + (id<{{.Config.APIPrefix}}SerializableModel>)parseResponse:(id)response asModel:(Class)modelClass;
 + (void)parseResponse:(id)response updatingModel:(id<{{.Config.APIPrefix}}SerializableModel>)modelInstance;
 
+/**
+ * Returns the class that must be instantiated for the dictionary. It is the model class itself unless
+ * the dictionary represents one of its variants
+ */
++ (Class)classOfModel:(Class)modelClass forDictionary:(id)dictionary;
+
+/**
+ * Converts a JSON value to models of the specified class nested in the given containers,
+ * from the outermost to the innermost: "array" or "map". Values that don't match the containers are converted to nil
+ */
++ (id)modelsFromJSONValue:(id)value containers:(NSArray<NSString *> *)containers modelClass:(Class)modelClass;
+
+/**
+ * Converts a model (or arrays or dictionaries of them, at any level of nesting) to JSON values.
+ * It's the counterpart of modelsFromJSONValue:containers:modelClass:
+ */
++ (id)JSONValueFromModels:(id)models;
+
+/**
+ * Converts a JSON value (or an array or dictionary of them) to dates using the specified format:
+ * "rfc3339", "isoDate", "epochSeconds" or "epochMillis". Values that are not valid dates are converted to nil
+ */
++ (id)datesFromJSONValue:(id)value format:(NSString *)format;
+
+/**
+ * Converts a date (or an array or dictionary of them) to JSON values using the specified format.
+ * It's the counterpart of datesFromJSONValue:format:
+ */
++ (id)JSONValueFromDates:(id)dates format:(NSString *)format inString:(BOOL)inString;
+
 @end
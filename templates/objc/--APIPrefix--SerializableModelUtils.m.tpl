@@ -3,6 +3,13 @@
 #import "{{.Config.APIPrefix}}SerializableModelUtils.h"
 #import "{{.Config.APIPrefix}}SerializableModelProtocol.h"
 
+static NSString *const kRFC3339DateFormat = @"rfc3339";
+static NSString *const kISODateDateFormat = @"isoDate";
+static NSString *const kEpochSecondsDateFormat = @"epochSeconds";
+static NSString *const kEpochMillisDateFormat = @"epochMillis";
+
+static NSString *const kArrayContainer = @"array";
+static NSString *const kMapContainer = @"map";
 
 @implementation {{.Config.APIPrefix}}SerializableModelUtils
 
@@ -37,7 +44,7 @@
 {
     NSAssert([modelClass conformsToProtocol:@protocol({{.Config.APIPrefix}}SerializableModel)], @"The model class must conform {{.Config.APIPrefix}}SerializableModel protocol");
 
-    id<{{.Config.APIPrefix}}SerializableModel> instance = (id <{{.Config.APIPrefix}}SerializableModel>) [modelClass new];
+    id<{{.Config.APIPrefix}}SerializableModel> instance = (id <{{.Config.APIPrefix}}SerializableModel>) [[self classOfModel:modelClass forDictionary:response] new];
     [self parseResponse:response updatingModel:instance];
     return instance;
 }
@@ -48,5 +55,206 @@
     [modelInstance updateWithDictionary:response];
 }
 
++ (Class)classOfModel:(Class)modelClass forDictionary:(id)dictionary
+{
+    if (![dictionary isKindOfClass:[NSDictionary class]] || ![modelClass respondsToSelector:@selector(classForDictionary:)])
+    {
+        return modelClass;
+    }
+    return [modelClass classForDictionary:dictionary];
+}
+
++ (id)modelsFromJSONValue:(id)value containers:(NSArray<NSString *> *)containers modelClass:(Class)modelClass
+{
+    if (containers.count == 0)
+    {
+        return [value isKindOfClass:[NSDictionary class]] ? [self parseResponse:value asModel:modelClass] : nil;
+    }
+
+    NSArray<NSString *> *innerContainers = [containers subarrayWithRange:NSMakeRange(1, containers.count - 1)];
+    if ([containers.firstObject isEqualToString:kArrayContainer])
+    {
+        if (![value isKindOfClass:[NSArray class]])
+        {
+            return nil;
+        }
+        NSMutableArray *models = [NSMutableArray new];
+        for (id item in value)
+        {
+            id model = [self modelsFromJSONValue:item containers:innerContainers modelClass:modelClass];
+            if (model != nil)
+            {
+                [models addObject:model];
+            }
+        }
+        return models;
+    }
+
+    if (![value isKindOfClass:[NSDictionary class]])
+    {
+        return nil;
+    }
+    NSMutableDictionary *models = [NSMutableDictionary new];
+    for (NSString *key in value)
+    {
+        models[key] = [self modelsFromJSONValue:value[key] containers:innerContainers modelClass:modelClass];
+    }
+    return models;
+}
+
++ (id)JSONValueFromModels:(id)models
+{
+    if ([models isKindOfClass:[NSArray class]])
+    {
+        NSMutableArray *values = [NSMutableArray new];
+        for (id model in models)
+        {
+            id value = [self JSONValueFromModels:model];
+            if (value != nil)
+            {
+                [values addObject:value];
+            }
+        }
+        return values;
+    }
+
+    if ([models isKindOfClass:[NSDictionary class]])
+    {
+        NSMutableDictionary *values = [NSMutableDictionary new];
+        for (NSString *key in models)
+        {
+            values[key] = [self JSONValueFromModels:models[key]];
+        }
+        return values;
+    }
+
+    if (![models conformsToProtocol:@protocol({{.Config.APIPrefix}}SerializableModel)])
+    {
+        return nil;
+    }
+    return [models toDictionary];
+}
+
++ (id)datesFromJSONValue:(id)value format:(NSString *)format
+{
+    if ([value isKindOfClass:[NSArray class]])
+    {
+        NSMutableArray *dates = [NSMutableArray new];
+        for (id item in value)
+        {
+            id date = [self datesFromJSONValue:item format:format];
+            if (date != nil)
+            {
+                [dates addObject:date];
+            }
+        }
+        return dates;
+    }
+
+    if ([value isKindOfClass:[NSDictionary class]])
+    {
+        NSMutableDictionary *dates = [NSMutableDictionary new];
+        for (NSString *key in value)
+        {
+            dates[key] = [self datesFromJSONValue:value[key] format:format];
+        }
+        return dates;
+    }
+
+    if ([format isEqualToString:kEpochSecondsDateFormat] || [format isEqualToString:kEpochMillisDateFormat])
+    {
+        if (![value isKindOfClass:[NSNumber class]] && ![value isKindOfClass:[NSString class]])
+        {
+            return nil;
+        }
+        NSTimeInterval interval = [value doubleValue];
+        if ([format isEqualToString:kEpochMillisDateFormat])
+        {
+            interval /= 1000;
+        }
+        return [NSDate dateWithTimeIntervalSince1970:interval];
+    }
+
+    if (![value isKindOfClass:[NSString class]])
+    {
+        return nil;
+    }
+
+    if ([format isEqualToString:kISODateDateFormat])
+    {
+        return [[self dateFormatterWithLayout:@"yyyy-MM-dd"] dateFromString:value];
+    }
+
+    NSDate *date = [[self dateFormatterWithLayout:@"yyyy-MM-dd'T'HH:mm:ssXXXXX"] dateFromString:value];
+    if (date == nil)
+    {
+        // Try with fractional seconds
+        date = [[self dateFormatterWithLayout:@"yyyy-MM-dd'T'HH:mm:ss.SSSXXXXX"] dateFromString:value];
+    }
+    return date;
+}
+
++ (id)JSONValueFromDates:(id)dates format:(NSString *)format inString:(BOOL)inString
+{
+    if ([dates isKindOfClass:[NSArray class]])
+    {
+        NSMutableArray *values = [NSMutableArray new];
+        for (id date in dates)
+        {
+            id value = [self JSONValueFromDates:date format:format inString:inString];
+            if (value != nil)
+            {
+                [values addObject:value];
+            }
+        }
+        return values;
+    }
+
+    if ([dates isKindOfClass:[NSDictionary class]])
+    {
+        NSMutableDictionary *values = [NSMutableDictionary new];
+        for (NSString *key in dates)
+        {
+            values[key] = [self JSONValueFromDates:dates[key] format:format inString:inString];
+        }
+        return values;
+    }
+
+    if (![dates isKindOfClass:[NSDate class]])
+    {
+        return nil;
+    }
+
+    NSDate *date = dates;
+    if ([format isEqualToString:kEpochSecondsDateFormat] || [format isEqualToString:kEpochMillisDateFormat])
+    {
+        NSTimeInterval interval = date.timeIntervalSince1970;
+        if ([format isEqualToString:kEpochMillisDateFormat])
+        {
+            interval *= 1000;
+        }
+        NSNumber *epoch = @((long long)interval);
+        return inString ? epoch.stringValue : epoch;
+    }
+
+    if ([format isEqualToString:kISODateDateFormat])
+    {
+        return [[self dateFormatterWithLayout:@"yyyy-MM-dd"] stringFromDate:date];
+    }
+
+    return [[self dateFormatterWithLayout:@"yyyy-MM-dd'T'HH:mm:ssXXXXX"] stringFromDate:date];
+}
+
+#pragma mark - Private methods
+
++ (NSDateFormatter *)dateFormatterWithLayout:(NSString *)layout
+{
+    NSDateFormatter *formatter = [NSDateFormatter new];
+    formatter.locale = [NSLocale localeWithLocaleIdentifier:@"en_US_POSIX"];
+    formatter.timeZone = [NSTimeZone timeZoneForSecondsFromGMT:0];
+    formatter.dateFormat = layout;
+    return formatter;
+}
+
 
 @end
@@ -0,0 +1,4 @@
+{{define "extensionHeaderComment" -}}
+// File generated once by SDKGen to extend the model. It is never overwritten, so add your own code here
+//
+{{- end}}
@@ -1,6 +1,8 @@
 {{define "preHeaderComment" -}}
 // File automatically generated by SDKGen. DO NOT edit manually
+{{- if not .Config.OmitTimestamp}}
 //
 //  Created on {{.CurrentTime.Format "2006/01/02 15:04:05 MST"}}
+{{- end}}
 //
 {{- end}}
\ No newline at end of file
@@ -2,7 +2,7 @@
 
 {{$resourceNameUpper := upperFirst .ResourceModel.OriginalName}}
 // TODO <Add doc about the response type>
-- (AnyPromise *){{.CRUDMethodName}}{{if .IsArrayResponse}}{{plural $resourceNameUpper}}{{else}}{{$resourceNameUpper}}{{end}}
+- (AnyPromise *){{.CRUDMethodName}}{{if .MethodResourceName}}{{upperFirst .MethodResourceName}}{{else if .IsArrayResponse}}{{plural $resourceNameUpper}}{{else}}{{$resourceNameUpper}}{{end}}
 
 {{- if .NeedsModelParam -}}
     :({{.RequestModel.Name}} *){{.RequestModel.OriginalName | lowerFirst}}
@@ -10,10 +10,11 @@
 {{- if .SegmentParams}}
     {{- if .NeedsModelParam}} with{{else}}With{{end}}
     {{- $n := len .SegmentParams -}}
-    {{- $first := index .SegmentParams 0 | singular | camelCase -}}
-    {{$first | upperFirst}}:(NSString *){{$first | sanitizeVariable -}}
+    {{- $first := index .SegmentParams 0 -}}
+    {{- $firstName := $first.Name | singular | camelCase -}}
+    {{$firstName | upperFirst}}:({{$first.TypeLabel}}){{$firstName | sanitizeVariable -}}
     {{range $index, $param := .SegmentParams -}}
-        {{if gt $index 0}} {{. | singular | camelCase}}:(NSString *){{. | sanitizeVariable | singular | camelCase}}{{end}}
+        {{if gt $index 0}} {{.Name | singular | camelCase}}:({{.TypeLabel}}){{.Name | sanitizeVariable | singular | camelCase}}{{end}}
     {{- end}}
 {{- end}}
 {{- if .URLQueryParams }}
@@ -25,6 +26,43 @@
 {{- end}}
 {{- end}}
 
+{{define "servicePagesMethodName" -}}
+{{template "serviceMethodName" .}}
+    onPage:(BOOL (^)(id page))onPage
+{{- end}}
+
+{{define "serviceURLPath" -}}
+{{if .Endpoint.SegmentParams -}}
+    NSMutableDictionary *segmentParams = [NSMutableDictionary new];
+    {{range .Endpoint.SegmentParams -}}
+        segmentParams[@"{{.Name}}"] = {{segmentParamString . (.Name | sanitizeVariable | singular | camelCase)}};
+    {{end -}}
+    NSString *urlPath = [{{.Config.APIPrefix}}URLHelper replaceSegmentParams:segmentParams inURL:@"{{.Endpoint.URLPath}}"];
+    {{- else -}}
+    NSString *urlPath = @"{{.Endpoint.URLPath}}";
+{{- end}}
+{{- end}}
+
+{{define "serviceRequestParams" -}}
+{{if .NeedsModelParam}}[{{.RequestModel.OriginalName | lowerFirst}} toDictionary]{{else if .URLQueryParams}}query{{else}}nil{{end}}
+{{- end}}
+
+{{define "serviceParseBody" -}}
+{{if .Endpoint.Envelope -}}
+    id (^parsePayload)(id) = ^id(id response) {
+            {{template "serviceParseResponse" .}}
+        };
+        NSDictionary *envelope = [response isKindOfClass:[NSDictionary class]] ? response : nil;
+        {{- if .Endpoint.Envelope.MetaKey}}
+        return PMKManifold(parsePayload(envelope[@"{{.Endpoint.Envelope.DataKey}}"]), envelope[@"{{.Endpoint.Envelope.MetaKey}}"]);
+        {{- else}}
+        return parsePayload(envelope[@"{{.Endpoint.Envelope.DataKey}}"]);
+        {{- end}}
+{{- else -}}
+    {{template "serviceParseResponse" .}}
+{{- end}}
+{{- end}}
+
 {{define "serviceParseResponse" -}}
 
 {{if .Endpoint.Authenticates -}}
@@ -41,7 +79,9 @@
     {{- else -}}
         return [{{.Config.APIPrefix}}SerializableModelUtils parseResponse:response asModel:[{{.Endpoint.ResponseModel.Name}} class]];
     {{- end}}
-{{- else if .Endpoint.IsRawResponse | or .Endpoint.IsRawArrayResponse | or .Endpoint.IsRawMapResponse -}}
+{{- else if .Endpoint.IsBoolResponse -}}
+    return @([response boolValue]);
+{{- else if .Endpoint.IsRawResponse | or .Endpoint.IsRawArrayResponse | or .Endpoint.IsRawMapResponse | or .Endpoint.IsTextResponse | or .Endpoint.IsBinaryResponse -}}
     return response;
 {{- end}}
 
@@ -0,0 +1,11 @@
+{{template "extensionHeaderComment" .}}
+
+#import "{{.CurrentModelInfo.Name}}.h"
+
+NS_ASSUME_NONNULL_BEGIN
+
+@interface {{.CurrentModelInfo.Name}} (Custom)
+
+@end
+
+NS_ASSUME_NONNULL_END
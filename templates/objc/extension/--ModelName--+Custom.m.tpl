@@ -0,0 +1,7 @@
+{{template "extensionHeaderComment" .}}
+
+#import "{{.CurrentModelInfo.Name}}+Custom.h"
+
+@implementation {{.CurrentModelInfo.Name}} (Custom)
+
+@end
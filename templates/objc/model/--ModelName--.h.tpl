@@ -2,12 +2,31 @@
 
 #import <Foundation/Foundation.h>
 #import "{{.Config.APIPrefix}}SerializableModelProtocol.h"
-{{ range $dep, $_ := .CurrentModelInfo.ModelDependencies}}
+{{- if .CurrentModelInfo.Parent}}
+#import "{{.CurrentModelInfo.Parent.Name}}.h"
+{{- end}}
+{{- if .CurrentModelInfo.EnumDependencies}}
+#import "{{.Config.APIPrefix}}Enums.h"
+{{- end}}
+{{ range $dep := .CurrentModelInfo.SortedModelDependencies}}
 @class {{$dep.Name}};
 {{- end}}
 
+NS_ASSUME_NONNULL_BEGIN
+
+{{if .CurrentModelInfo.Variants -}}
+/**
+ * Base model of the variants discriminated by "{{.CurrentModelInfo.Discriminator}}". The unknown variants are instances of this class
+ */
+{{end -}}
+{{if .CurrentModelInfo.Parent -}}
+@interface {{.CurrentModelInfo.Name}} : {{.CurrentModelInfo.Parent.Name}}
+{{- else -}}
 @interface {{.CurrentModelInfo.Name}} : NSObject <{{.Config.APIPrefix}}SerializableModel>
+{{- end}}
 {{range .CurrentModelInfo.Properties -}}
-@property (nonatomic) {{.TypeLabel}}{{.NameLabel | sanitizeProperty}};
+@property (nonatomic{{if isNullableObject .}}, nullable{{end}}) {{.TypeLabel}}{{.NameLabel | sanitizeProperty}};
 {{end -}}
-@end
\ No newline at end of file
+@end
+
+NS_ASSUME_NONNULL_END
\ No newline at end of file
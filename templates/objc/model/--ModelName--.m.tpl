@@ -1,9 +1,13 @@
 {{template "preHeaderComment" .}}
 
 #import "{{.CurrentModelInfo.Name}}.h"
-{{ range $dep, $_ := .CurrentModelInfo.ModelDependencies}}
+#import "{{.Config.APIPrefix}}SerializableModelUtils.h"
+{{ range $dep := .CurrentModelInfo.SortedModelDependencies}}
 #import "{{$dep.Name}}.h"
 {{- end}}
+{{- range $_, $variant := .CurrentModelInfo.Variants}}
+#import "{{$variant.Name}}.h"
+{{- end}}
 
 @implementation {{.CurrentModelInfo.Name}}
 
@@ -18,63 +22,57 @@
 
 - (void)updateWithDictionary:(NSDictionary *)dictionary
 {
+{{- if .CurrentModelInfo.Parent}}
+    [super updateWithDictionary:dictionary];
+{{- end}}
 {{- range .CurrentModelInfo.Properties}}
     {{ if $.CurrentModelInfo.DependsOnModel .Type -}}
-        {{- if .IsArray}}
-    NSMutableArray *itemsOf{{.Type}} = [NSMutableArray new];
-    NSArray *itemDictionariesOf{{.Type}} = dictionary[@"{{.Name}}"];
-    for (NSDictionary *itemDictionary in itemDictionariesOf{{.Type}})
-    {
-        [itemsOf{{.Type}} addObject:[[{{.Type}} alloc] initWithDictionary:itemDictionary]];
-    }
-    self.{{.NameLabel | sanitizeProperty}} = itemsOf{{.Type}};
-        {{else if .IsMap -}}
-    NSMutableDictionary *dictionaryOf{{.Type}} = [NSMutableDictionary new];
-    NSDictionary *rawDictionaryOf{{.Type}} = dictionary[@"{{.Name}}"];
-    for (NSString *key in rawDictionaryOf{{.Type}})
-    {
-        dictionaryOf{{.Type}}[key] = [[{{.Type}} alloc] initWithDictionary:rawDictionaryOf{{.Type}}[key]];
-    }
-    self.{{.NameLabel | sanitizeProperty}} = dictionaryOf{{.Type}};
-        {{else -}}
-    self.{{.NameLabel | sanitizeProperty}} = [[{{.Type}} alloc] initWithDictionary:dictionary[@"{{.Name}}"]];
+        {{- if .Containers}}
+    self.{{.NameLabel | sanitizeProperty}} = [{{$.Config.APIPrefix}}SerializableModelUtils modelsFromJSONValue:dictionary[@"{{.Name}}"] containers:{{containersLiteral .}} modelClass:[{{.Type}} class]]{{if not (isNullableObject .)}} ?: {{if .IsArray}}@[]{{else}}@{}{{end}}{{end}};
+        {{- else -}}
+        {{- if isNullableObject .}}
+    self.{{.NameLabel | sanitizeProperty}} = [dictionary[@"{{.Name}}"] isKindOfClass:[NSDictionary class]] ? [[[{{$.Config.APIPrefix}}SerializableModelUtils classOfModel:[{{.Type}} class] forDictionary:dictionary[@"{{.Name}}"]] alloc] initWithDictionary:dictionary[@"{{.Name}}"]] : nil;
+        {{- else}}
+    self.{{.NameLabel | sanitizeProperty}} = [[[{{$.Config.APIPrefix}}SerializableModelUtils classOfModel:[{{.Type}} class] forDictionary:dictionary[@"{{.Name}}"]] alloc] initWithDictionary:dictionary[@"{{.Name}}"]];
+        {{- end}}
         {{- end}}
     {{- else -}}
-        self.{{.NameLabel | sanitizeProperty}} = {{if eq .Type "BOOL"}}[dictionary[@"{{.Name}}"] boolValue]{{else}}dictionary[@"{{.Name}}"]{{end}};
+        self.{{.NameLabel | sanitizeProperty}} = {{if .Enum}}{{.Enum.Name}}FromString(dictionary[@"{{.Name}}"]){{else if isDate .}}[{{$.Config.APIPrefix}}SerializableModelUtils datesFromJSONValue:dictionary[@"{{.Name}}"] format:@"{{.DateFormat}}"]{{else if isFile .}}[dictionary[@"{{.Name}}"] isKindOfClass:[NSURL class]] ? dictionary[@"{{.Name}}"] : nil{{else if unboxSelector .}}[dictionary[@"{{.Name}}"] {{unboxSelector .}}]{{else if isNullableObject .}}dictionary[@"{{.Name}}"] != [NSNull null] ? dictionary[@"{{.Name}}"] : nil{{else}}dictionary[@"{{.Name}}"]{{end}};
     {{- end}}
 {{- end}}
 }
 
 - (NSDictionary *)toDictionary
 {
-    NSMutableDictionary *dictionary = [NSMutableDictionary dictionary];
+    NSMutableDictionary *dictionary = {{if .CurrentModelInfo.Parent}}[[super toDictionary] mutableCopy]{{else}}[NSMutableDictionary dictionary]{{end}};
 {{ range .CurrentModelInfo.Properties}}
     {{ if $.CurrentModelInfo.DependsOnModel .Type -}}
-        {{- if .IsArray}}
-    NSMutableArray *itemDictionariesOf{{.Type}} = [NSMutableArray new];
-    for ({{.Type}} *item in self.{{.NameLabel | sanitizeProperty}})
-    {
-        [itemDictionariesOf{{.Type}} addObject:[item toDictionary]];
-    }
-    dictionary[@"{{.Name}}"] = itemDictionariesOf{{.Type}};
-        {{- else if .IsMap -}}
-
-    NSMutableDictionary *rawDictionaryOf{{.Type}} = [NSMutableDictionary new];
-    for (NSString *key in self.{{.NameLabel | sanitizeProperty}})
-    {
-        {{.Type}} *item = self.{{.NameLabel | sanitizeProperty}}[key];
-        rawDictionaryOf{{.Type}}[key] = [item toDictionary];
-    }
-    dictionary[@"{{.Name}}"] = rawDictionaryOf{{.Type}};
-        {{- else -}}
+        {{- if .Containers}}
+    dictionary[@"{{.Name}}"] = [{{$.Config.APIPrefix}}SerializableModelUtils JSONValueFromModels:self.{{.NameLabel | sanitizeProperty}}];
+        {{- else}}
     dictionary[@"{{.Name}}"] = [self.{{.NameLabel | sanitizeProperty}} toDictionary];
         {{- end}}
     {{- else -}}
-        dictionary[@"{{.Name}}"] = {{if eq .Type "BOOL"}}@(self.{{.NameLabel | sanitizeProperty}}){{else}}self.{{.NameLabel | sanitizeProperty}}{{end}};
+        dictionary[@"{{.Name}}"] = {{if .Enum}}{{.Enum.Name}}ToString(self.{{.NameLabel | sanitizeProperty}}){{else if isDate .}}[{{$.Config.APIPrefix}}SerializableModelUtils JSONValueFromDates:self.{{.NameLabel | sanitizeProperty}} format:@"{{.DateFormat}}" inString:{{if .DateInString}}YES{{else}}NO{{end}}]{{else if unboxSelector .}}@(self.{{.NameLabel | sanitizeProperty}}){{else}}self.{{.NameLabel | sanitizeProperty}}{{end}};
     {{- end}}
 {{- end}}
 
     return dictionary;
 }
+{{- if .CurrentModelInfo.Variants}}
+
++ (Class)classForDictionary:(NSDictionary *)dictionary
+{
+    id discriminator = dictionary[@"{{.CurrentModelInfo.Discriminator}}"];
+{{- range $value, $variant := .CurrentModelInfo.Variants}}
+    if ([discriminator isEqual:@"{{$value}}"])
+    {
+        return [{{$variant.Name}} class];
+    }
+{{- end}}
+    // Unknown variants are instantiated as this class
+    return self;
+}
+{{- end}}
 
 @end
\ No newline at end of file
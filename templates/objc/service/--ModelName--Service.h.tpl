@@ -4,7 +4,7 @@
 #import "{{.Config.APIPrefix}}ServiceProtocol.h"
 #import <PromiseKit/PromiseKit.h>
 
-{{ range $dep, $_ := .CurrentModelInfo.EndpointsDependencies }}
+{{ range $dep := .CurrentModelInfo.SortedEndpointsDependencies }}
 @class {{$dep.Name}};
 {{- end}}
 
@@ -14,6 +14,9 @@
 {{- $model := .CurrentModelInfo}}
 {{range $model.EndpointsInfo -}}
 {{template "serviceMethodName" .}};
+{{- if .Pagination}}
+{{template "servicePagesMethodName" .}};
+{{ end}}
 {{- end}}
 
 @end
\ No newline at end of file
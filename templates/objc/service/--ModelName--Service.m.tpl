@@ -2,12 +2,15 @@
 {{- $model := .CurrentModelInfo}}
 
 #import "{{$model.Name}}Service.h"
-{{ range $dep, $_ := .CurrentModelInfo.EndpointsDependencies }}
+{{ range $dep := .CurrentModelInfo.SortedEndpointsDependencies }}
 #import "{{$dep.Name}}.h"
 {{- end}}
 #import "{{.Config.APIPrefix}}ResourceManager.h"
 #import "{{.Config.APIPrefix}}URLHelper.h"
 #import "{{.Config.APIPrefix}}SerializableModelUtils.h"
+{{- if $model.HasPaginatedEndpoints}}
+#import "{{.Config.APIPrefix}}Paginator.h"
+{{- end}}
 
 @interface {{$model.Name}}Service ()
 @property (nonatomic, weak) {{.Config.APIPrefix}}ResourceManager *resourceManager;
@@ -27,34 +30,55 @@
 {{range $model.EndpointsInfo -}}
 {{template "serviceMethodName" .}}
 {
-    {{if .SegmentParams -}}
-    NSMutableDictionary *segmentParams = [NSMutableDictionary new];
-    {{range .SegmentParams -}}
-        segmentParams[@"{{.}}"] = {{. | sanitizeVariable | singular | camelCase}};
-    {{end -}}
-    NSString *urlPath = [{{$.Config.APIPrefix}}URLHelper replaceSegmentParams:segmentParams inURL:@"{{.URLPath}}"];
-    {{- else -}}
-    NSString *urlPath = @"{{.URLPath}}";
-    {{- end}}
+    {{template "serviceURLPath" dict "Endpoint" . "Config" $.Config}}
 
     {{- if .URLQueryParams | and .NeedsModelParam }}
     urlPath = [urlPath stringByAppendingString:[{{$.Config.APIPrefix}}URLHelper encodeQueryStringFromDictionary:query]];
     {{- end}}
 
     {{if .Authenticates}}{{$.Config.APIPrefix}}ResourceManager *resourceManager = self.resourceManager;{{end}}
+    {{- if .HasJSONBodies}}
     return [self.resourceManager {{.Method.String | lower}}ResourceWithURLPath:urlPath
-                                                 params:{{if .NeedsModelParam -}}
-                                                            [{{.RequestModel.OriginalName | lowerFirst}} toDictionary]
-                                                        {{- else -}}
-                                                            {{if .URLQueryParams }}query{{else}}nil{{end}}
-                                                        {{- end}}]
+                                                 params:{{template "serviceRequestParams" .}}]
+    {{- else}}
+    return [self.resourceManager requestResourceWithMethod:@"{{.Method}}"
+                                                   URLPath:urlPath
+                                                    params:{{template "serviceRequestParams" .}}
+                                             requestFormat:{{$.Config.APIPrefix}}RequestFormat{{requestFormatName .}}
+                                            responseFormat:{{$.Config.APIPrefix}}ResponseFormat{{responseFormatName .}}]
+    {{- end}}
     {{- if .HasResponse}}
     .then(^(id response) {
-        {{template "serviceParseResponse" dict "Endpoint" . "Config" $.Config}}
+        {{template "serviceParseBody" dict "Endpoint" . "Config" $.Config}}
     });
     {{- else -}}
     ;
     {{- end}}
 }
+{{if .Pagination -}}
+{{template "servicePagesMethodName" .}}
+{
+    {{template "serviceURLPath" dict "Endpoint" . "Config" $.Config}}
+    {{$.Config.APIPrefix}}Paginator *paginator = [{{$.Config.APIPrefix}}Paginator paginatorWithPagination:{{$.Config.APIPrefix}}Pagination{{paginationName .Pagination}}
+                                                            param:@"{{.Pagination.Param}}"
+                                                    pageSizeParam:{{if .Pagination.PageSizeParam}}@"{{.Pagination.PageSizeParam}}"{{else}}nil{{end}}
+                                                     itemsKeyPath:{{if .Pagination.ItemsKeyPath}}@"{{.Pagination.ItemsKeyPath}}"{{else}}nil{{end}}
+                                                      nextKeyPath:{{if .Pagination.NextKeyPath}}@"{{.Pagination.NextKeyPath}}"{{else}}nil{{end}}];
+    id (^parsePage)(id) = ^id(id response) {
+        {{- if .Envelope}}
+        response = [response isKindOfClass:[NSDictionary class]] ? response[@"{{.Envelope.DataKey}}"] : nil;
+        {{- end}}
+        {{template "serviceParseResponse" dict "Endpoint" . "Config" $.Config}}
+    };
+    typeof (self) __weak weakSelf = self;
+    return [paginator iteratePagesWithQuery:query
+                                  fetchPage:^AnyPromise *(NSDictionary *pageQuery) {
+                                      return [weakSelf.resourceManager {{.Method.String | lower}}ResourceWithURLPath:urlPath params:pageQuery];
+                                  }
+                                     onPage:^BOOL(id response) {
+                                         return onPage(parsePage(response));
+                                     }];
+}
+{{end -}}
 {{end}}
 @end
\ No newline at end of file
@@ -0,0 +1,125 @@
+package watch
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Watcher checks periodically whether the files of some paths change. It polls the files instead of
+// relying on the notifications of the OS, as the editors save them in very different ways
+type Watcher struct {
+	// paths are files or directories. The files in the directories are checked recursively
+	paths    []string
+	interval time.Duration
+	debounce time.Duration
+}
+
+// fileState is what changes in a file when it is written
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// New returns a watcher checking the paths every interval. The changes are reported once the files
+// stay unchanged during the debounce time
+func New(paths []string, interval, debounce time.Duration) *Watcher {
+	return &Watcher{
+		paths:    paths,
+		interval: interval,
+		debounce: debounce,
+	}
+}
+
+// Watch checks the files until the stop channel is closed, calling the function with the paths of the changed files.
+// Several changes in a row, like the ones of an editor saving a file, result in only one call
+func (w *Watcher) Watch(stop <-chan struct{}, onChange func(changedPaths []string)) {
+	states := w.fileStates()
+	pending := newPendingChanges(w.debounce)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		now := time.Now()
+		newStates := w.fileStates()
+		pending.add(changedPaths(states, newStates), now)
+		states = newStates
+		if paths := pending.flush(now); len(paths) > 0 {
+			onChange(paths)
+		}
+	}
+}
+
+// pendingChanges gathers the changed paths until no path changes during the debounce time
+type pendingChanges struct {
+	debounce   time.Duration
+	paths      map[string]struct{}
+	lastChange time.Time
+}
+
+func newPendingChanges(debounce time.Duration) *pendingChanges {
+	return &pendingChanges{
+		debounce: debounce,
+		paths:    map[string]struct{}{},
+	}
+}
+
+// add records the paths changed at the given time
+func (pc *pendingChanges) add(paths []string, now time.Time) {
+	for _, path := range paths {
+		pc.paths[path] = struct{}{}
+		pc.lastChange = now
+	}
+}
+
+// flush returns the sorted changed paths and forgets them if the debounce time has passed since the
+// last change. Otherwise, it returns nothing
+func (pc *pendingChanges) flush(now time.Time) []string {
+	if len(pc.paths) == 0 || now.Sub(pc.lastChange) < pc.debounce {
+		return nil
+	}
+	paths := make([]string, 0, len(pc.paths))
+	for path := range pc.paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	pc.paths = map[string]struct{}{}
+	return paths
+}
+
+// fileStates returns the state of the files of the watched paths. The paths that can't be read,
+// like the files being replaced, are ignored
+func (w *Watcher) fileStates() map[string]fileState {
+	states := map[string]fileState{}
+	for _, watchedPath := range w.paths {
+		filepath.Walk(watchedPath, func(path string, info os.FileInfo, err error) error {
+			if err == nil && info.Mode().IsRegular() {
+				states[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+			}
+			return nil
+		})
+	}
+	return states
+}
+
+// changedPaths returns the paths of the files created, modified or removed
+func changedPaths(oldStates, newStates map[string]fileState) []string {
+	var paths []string
+	for path, newState := range newStates {
+		if oldState, found := oldStates[path]; !found || !oldState.modTime.Equal(newState.modTime) || oldState.size != newState.size {
+			paths = append(paths, path)
+		}
+	}
+	for path := range oldStates {
+		if _, found := newStates[path]; !found {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
@@ -0,0 +1,114 @@
+package watch
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestChangedPaths(t *testing.T) {
+	modTime := time.Date(2017, 3, 1, 10, 0, 0, 0, time.UTC)
+	oldStates := map[string]fileState{
+		"api.sas":             {modTime: modTime, size: 10},
+		"templates/model.tpl": {modTime: modTime, size: 20},
+		"templates/enum.tpl":  {modTime: modTime, size: 30},
+		"templates/api.tpl":   {modTime: modTime, size: 40},
+	}
+	newStates := map[string]fileState{
+		"api.sas":             {modTime: modTime.Add(time.Second), size: 10},
+		"templates/model.tpl": {modTime: modTime, size: 21},
+		"templates/api.tpl":   {modTime: modTime, size: 40},
+		"templates/new.tpl":   {modTime: modTime, size: 50},
+	}
+
+	paths := changedPaths(oldStates, newStates)
+	sort.Strings(paths)
+	expectedPaths := []string{"api.sas", "templates/enum.tpl", "templates/model.tpl", "templates/new.tpl"}
+	if !reflect.DeepEqual(paths, expectedPaths) {
+		t.Errorf("Expected the changes of %v, got: %v", expectedPaths, paths)
+	}
+	if paths := changedPaths(newStates, newStates); len(paths) > 0 {
+		t.Errorf("Expected no changes, got: %v", paths)
+	}
+}
+
+func TestPendingChanges(t *testing.T) {
+	start := time.Date(2017, 3, 1, 10, 0, 0, 0, time.UTC)
+	pending := newPendingChanges(300 * time.Millisecond)
+
+	if paths := pending.flush(start); len(paths) > 0 {
+		t.Errorf("Expected no changes before any, got: %v", paths)
+	}
+
+	// Several changes in a row are reported once, after the last one
+	pending.add([]string{"api.sas"}, start)
+	pending.add([]string{"templates/model.tpl", "api.sas"}, start.Add(200*time.Millisecond))
+	if paths := pending.flush(start.Add(400 * time.Millisecond)); len(paths) > 0 {
+		t.Errorf("Expected no changes during the debounce time, got: %v", paths)
+	}
+	expectedPaths := []string{"api.sas", "templates/model.tpl"}
+	if paths := pending.flush(start.Add(500 * time.Millisecond)); !reflect.DeepEqual(paths, expectedPaths) {
+		t.Errorf("Expected the changes of %v, got: %v", expectedPaths, paths)
+	}
+	if paths := pending.flush(start.Add(time.Second)); len(paths) > 0 {
+		t.Errorf("Expected the changes to be reported once, got: %v", paths)
+	}
+
+	// Checks without changes don't delay the report
+	pending.add(nil, start.Add(2*time.Second))
+	pending.add([]string{"templates/enum.tpl"}, start.Add(3*time.Second))
+	pending.add(nil, start.Add(3100*time.Millisecond))
+	expectedPaths = []string{"templates/enum.tpl"}
+	if paths := pending.flush(start.Add(3300 * time.Millisecond)); !reflect.DeepEqual(paths, expectedPaths) {
+		t.Errorf("Expected the changes of %v, got: %v", expectedPaths, paths)
+	}
+}
+
+// TestWatch checks the real files. The margins are wide, as the watcher depends on the scheduler
+func TestWatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sdkgen-watch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	specPath := filepath.Join(dir, "api.sas")
+	templatesDir := filepath.Join(dir, "templates")
+	if err := ioutil.WriteFile(specPath, []byte("GET /posts"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(templatesDir, 0777); err != nil {
+		t.Fatal(err)
+	}
+
+	changes := make(chan []string, 10)
+	stop := make(chan struct{})
+	defer close(stop)
+	watcher := New([]string{specPath, templatesDir}, 10*time.Millisecond, 500*time.Millisecond)
+	// The initial state is read before the files are changed
+	go watcher.Watch(stop, func(changedPaths []string) {
+		changes <- changedPaths
+	})
+	time.Sleep(100 * time.Millisecond)
+
+	templatePath := filepath.Join(templatesDir, "model.h.tpl")
+	if err := ioutil.WriteFile(specPath, []byte("GET /posts\nGET /users"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(templatePath, []byte("@interface"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case paths := <-changes:
+		expectedPaths := []string{specPath, templatePath}
+		if !reflect.DeepEqual(paths, expectedPaths) {
+			t.Errorf("Expected the changes of %v, got: %v", expectedPaths, paths)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Expected the changes to be reported, got none")
+	}
+}